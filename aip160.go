@@ -0,0 +1,286 @@
+package query
+
+import (
+	"strings"
+)
+
+var (
+	aipSymbols    = []string{"(", ")", "<=", ">=", "!=", "<", ">", "=", ":", "-"}
+	aipDelimiters = "()<>=!:"
+
+	aipFilterValues = map[string]string{
+		"=":  FilterEquals,
+		"!=": FilterNotEquals,
+		"<":  FilterLessThan,
+		"<=": FilterLessThanEquals,
+		">":  FilterGreaterThan,
+		">=": FilterGreateThanEquals,
+		":":  FilterEquals,
+	}
+)
+
+type aipParser struct {
+	parser *Parser
+	lexer  *lexer
+	depth  int
+}
+
+func (p *Parser) parseAIP160(raw string) (Expr, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	a := aipParser{
+		parser: p,
+		lexer:  newLexer(raw, aipSymbols, aipDelimiters, `"'`, true),
+	}
+
+	expr, err := a.parse()
+
+	return expr, wrapSyntaxError(ParamFilter, raw, err)
+}
+
+func (a *aipParser) parse() (Expr, error) {
+	expr, err := a.parseExpression()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := a.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t.kind != tokenEOF {
+		return nil, a.lexer.errorf(t.pos, "unexpected %s", t)
+	}
+
+	return expr, nil
+}
+
+func (a *aipParser) parseExpression() (Expr, error) {
+	expr, err := a.parseSequence()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{expr}
+
+	for {
+		t, err := a.lexer.peek()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.is(tokenWord, "AND") {
+			break
+		}
+
+		a.lexer.next()
+
+		if expr, err = a.parseSequence(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return newAndExpr(exprs), nil
+}
+
+func (a *aipParser) parseSequence() (Expr, error) {
+	expr, err := a.parseFactor()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{expr}
+
+	for {
+		t, err := a.lexer.peek()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t.kind == tokenEOF || t.is(tokenSymbol, ")") || t.is(tokenWord, "AND") {
+			break
+		}
+
+		if expr, err = a.parseFactor(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return newAndExpr(exprs), nil
+}
+
+func (a *aipParser) parseFactor() (Expr, error) {
+	expr, err := a.parseTerm()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{expr}
+
+	for {
+		t, err := a.lexer.peek()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.is(tokenWord, "OR") {
+			break
+		}
+
+		a.lexer.next()
+
+		if expr, err = a.parseTerm(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return newOrExpr(exprs), nil
+}
+
+func (a *aipParser) parseTerm() (Expr, error) {
+	t, err := a.lexer.peek()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.is(tokenWord, "NOT") && !t.is(tokenSymbol, "-") {
+		return a.parseSimple()
+	}
+
+	a.lexer.next()
+
+	expr, err := a.parseSimple()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NotExpr{Expr: expr}, nil
+}
+
+func (a *aipParser) parseSimple() (Expr, error) {
+	t, err := a.lexer.peek()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.is(tokenSymbol, "(") {
+		return a.parseRestriction()
+	}
+
+	if a.depth >= maxExprDepth {
+		return nil, a.lexer.errorf(t.pos, "expression is nested too deeply")
+	}
+
+	a.lexer.next()
+	a.depth++
+
+	expr, err := a.parseExpression()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.lexer.expect(tokenSymbol, ")"); err != nil {
+		return nil, err
+	}
+
+	a.depth--
+
+	return expr, nil
+}
+
+func (a *aipParser) parseRestriction() (Expr, error) {
+	comparable, err := a.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comparable.kind != tokenWord {
+		return nil, a.lexer.errorf(comparable.pos, "expected field, got %s", comparable)
+	}
+
+	comparator, err := a.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter, ok := aipFilterValues[comparator.text]
+
+	if comparator.kind != tokenSymbol || !ok {
+		return nil, a.lexer.errorf(comparator.pos, "expected comparator, got %s", comparator)
+	}
+
+	arg, err := a.parseArg()
+
+	if err != nil {
+		return nil, err
+	}
+
+	literal := arg.kind == tokenWord
+	args := []string{arg.text}
+
+	switch {
+	case comparator.text == ":" && literal && arg.text == "*":
+		filter, args = FilterNotNull, nil
+	case comparator.text == "=" || comparator.text == "!=":
+		if !literal {
+			filter, args[0] = a.parser.wildcardFilter(comparable.text, filter, args[0])
+		}
+	}
+
+	filtering, err := a.parser.newExprFiltering(ParamFilter, comparable.text, filter, args, literal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filtering, nil
+}
+
+func (a *aipParser) parseArg() (token, error) {
+	t, err := a.lexer.next()
+
+	if err != nil {
+		return t, err
+	}
+
+	if t.is(tokenSymbol, "-") && a.lexer.adjacent() {
+		value, err := a.lexer.next()
+
+		if err != nil {
+			return value, err
+		}
+
+		if value.kind == tokenWord {
+			return token{kind: tokenWord, text: "-" + value.text, pos: t.pos}, nil
+		}
+	}
+
+	if t.kind != tokenWord && t.kind != tokenString {
+		return t, a.lexer.errorf(t.pos, "expected value, got %s", t)
+	}
+
+	return t, nil
+}
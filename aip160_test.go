@@ -0,0 +1,74 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIP160(t *testing.T) {
+	postParser := query.MustParser(query.NewParser[examplePost]()).WithFilterSyntax(query.FilterSyntaxAIP160)
+	aipTicketParser := query.MustParser(query.NewParser[exampleTicket]()).WithFilterSyntax(query.FilterSyntaxAIP160)
+
+	t.Run("precedence", func(t *testing.T) {
+		where, err := postParser.ParseFilter(`author.first_name = "x" AND id > 3 OR title:"Go"`)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "author.first_name", Filter: query.FilterEquals, Value: "x", Requested: "author.first_name", Column: "author.first_name"},
+			query.OrExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "id", Filter: query.FilterGreaterThan, Value: 3, Requested: "id", Column: "id"},
+				query.Filtering{Field: "title", Filter: query.FilterEquals, Value: "Go", Requested: "title", Column: "title"},
+			}},
+		}}, where, "OR should bind tighter than AND")
+	})
+
+	t.Run("sequence-and-negation", func(t *testing.T) {
+		where, err := aipTicketParser.ParseFilter(`priority >= -1 -status = "closed" NOT (id = 1 OR id = 2) assignee:*`)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "priority", Filter: query.FilterGreateThanEquals, Value: -1, Requested: "priority", Column: "priority"},
+			query.NotExpr{Expr: query.Filtering{Field: "status", Filter: query.FilterEquals, Value: "closed", Requested: "status", Column: "status"}},
+			query.NotExpr{Expr: query.OrExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "id", Filter: query.FilterEquals, Value: 1, Requested: "id", Column: "id"},
+				query.Filtering{Field: "id", Filter: query.FilterEquals, Value: 2, Requested: "id", Column: "id"},
+			}}},
+			query.Filtering{Field: "assignee", Filter: query.FilterNotNull, Requested: "assignee", Column: "assignee"},
+		}}, where, "expression should be equal")
+	})
+
+	t.Run("wildcards-and-null", func(t *testing.T) {
+		where, err := aipTicketParser.ParseFilter(`status = "*open" AND status != "a*" AND assignee = null`)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "status", Filter: query.FilterLike, Value: "%open", Requested: "status", Column: "status"},
+			query.Filtering{Field: "status", Filter: query.FilterNotLike, Value: "a%", Requested: "status", Column: "status"},
+			query.Filtering{Field: "assignee", Filter: query.FilterIsNull, Requested: "assignee", Column: "assignee"},
+		}}, where, "expression should be equal")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := postParser.ParseFilter(`author.middle_name = "x"`)
+
+		assert.ErrorIs(t, err, query.ErrUnknownField, "should reject unknown field path")
+
+		var parsingError query.ParsingError
+
+		assert.ErrorAs(t, err, &parsingError, "should return a parsing error")
+
+		_, err = postParser.ParseFilter(`title "x"`)
+
+		var syntaxError query.SyntaxError
+
+		if assert.ErrorAs(t, err, &syntaxError, "should return a syntax error") {
+			assert.Equal(t, 6, syntaxError.Pos)
+		}
+
+		_, err = postParser.ParseFilter(`id = 1 AND`)
+
+		assert.ErrorAs(t, err, &syntaxError, "should return a syntax error")
+	})
+}
@@ -0,0 +1,425 @@
+package query
+
+import (
+	"iter"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type truth int
+
+const (
+	truthFalse truth = iota
+	truthTrue
+	truthUnknown
+)
+
+type evaluator struct {
+	fields map[string]field
+}
+
+type matcher struct {
+	*evaluator
+	likes map[string]*regexp.Regexp
+}
+
+var evaluatorCache sync.Map
+
+func Apply[T any](q Query, items []T) []T {
+	return slices.Collect(ApplySeq(q, slices.Values(items)))
+}
+
+func ApplySeq[T any](q Query, items iter.Seq[T]) iter.Seq[T] {
+	e := evaluatorFor(reflect.TypeFor[T]())
+
+	return func(yield func(T) bool) {
+		m := matcher{evaluator: e, likes: make(map[string]*regexp.Regexp)}
+
+		var matches iter.Seq[T] = func(yield func(T) bool) {
+			for item := range items {
+				if m.matches(q, reflect.ValueOf(item)) && !yield(item) {
+					return
+				}
+			}
+		}
+
+		if len(q.Sortings) > 0 {
+			matches = sortedSeq(e, q, matches)
+		}
+
+		skipped, taken := 0, 0
+
+		for item := range matches {
+			if skipped < q.Offset && q.Cursor == nil {
+				skipped++
+				continue
+			}
+
+			taken++
+
+			if !yield(item) || (q.Limit > 0 && taken >= q.Limit) {
+				return
+			}
+		}
+	}
+}
+
+func sortedSeq[T any](e *evaluator, q Query, items iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sorted := slices.SortedStableFunc(items, func(a T, b T) int {
+			return e.compare(q.Sortings, reflect.ValueOf(a), reflect.ValueOf(b))
+		})
+
+		if q.Cursor != nil {
+			sorted = slices.DeleteFunc(sorted, func(item T) bool {
+				c := e.compareCursor(q.Sortings, q.Cursor.Keys, reflect.ValueOf(item))
+
+				if q.Cursor.Direction == CursorPrev {
+					return c >= 0
+				}
+
+				return c <= 0
+			})
+
+			if q.Cursor.Direction == CursorPrev && q.Limit > 0 && len(sorted) > q.Limit {
+				sorted = sorted[len(sorted)-q.Limit:]
+			}
+		}
+
+		for _, item := range sorted {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func evaluatorFor(typ reflect.Type) *evaluator {
+	if e, ok := evaluatorCache.Load(typ); ok {
+		return e.(*evaluator)
+	}
+
+	structType := typ
+
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	e := &evaluator{fields: make(map[string]field)}
+
+	if structType.Kind() == reflect.Struct {
+		fields, _ := getFieldsFromReflectStruct(structType, false)
+
+		for _, f := range fields {
+			e.fields[f.name] = f
+		}
+	}
+
+	actual, _ := evaluatorCache.LoadOrStore(typ, e)
+
+	return actual.(*evaluator)
+}
+
+func (m matcher) matches(q Query, v reflect.Value) bool {
+	for _, filtering := range q.Filterings {
+		if m.filtering(filtering, v) != truthTrue {
+			return false
+		}
+	}
+
+	return q.Where == nil || m.expr(q.Where, v) == truthTrue
+}
+
+func (m matcher) expr(expr Expr, v reflect.Value) truth {
+	switch x := expr.(type) {
+	case Filtering:
+		return m.filtering(x, v)
+	case AndExpr:
+		result := truthTrue
+
+		for _, expr := range x.Exprs {
+			switch m.expr(expr, v) {
+			case truthFalse:
+				return truthFalse
+			case truthUnknown:
+				result = truthUnknown
+			}
+		}
+
+		return result
+	case OrExpr:
+		result := truthFalse
+
+		for _, expr := range x.Exprs {
+			switch m.expr(expr, v) {
+			case truthTrue:
+				return truthTrue
+			case truthUnknown:
+				result = truthUnknown
+			}
+		}
+
+		return result
+	case NotExpr:
+		switch m.expr(x.Expr, v) {
+		case truthTrue:
+			return truthFalse
+		case truthFalse:
+			return truthTrue
+		}
+	}
+
+	return truthUnknown
+}
+
+func (m matcher) filtering(filtering Filtering, v reflect.Value) truth {
+	f, ok := m.fields[filtering.Field]
+
+	if !ok {
+		return truthUnknown
+	}
+
+	value := f.value(v)
+
+	switch filtering.Filter {
+	case FilterIsNull:
+		return toTruth(value == nil)
+	case FilterNotNull:
+		return toTruth(value != nil)
+	}
+
+	if value == nil {
+		return truthUnknown
+	}
+
+	switch filtering.Filter {
+	case FilterEquals:
+		return toTruth(equalValues(value, filtering.Value))
+	case FilterNotEquals:
+		return toTruth(!equalValues(value, filtering.Value))
+	case FilterLessThan:
+		return compareTruth(value, filtering.Value, func(c int) bool { return c < 0 })
+	case FilterLessThanEquals:
+		return compareTruth(value, filtering.Value, func(c int) bool { return c <= 0 })
+	case FilterGreaterThan:
+		return compareTruth(value, filtering.Value, func(c int) bool { return c > 0 })
+	case FilterGreateThanEquals:
+		return compareTruth(value, filtering.Value, func(c int) bool { return c >= 0 })
+	case FilterIn:
+		return containsValue(filtering.Value, value)
+	case FilterNotIn:
+		return notTruth(containsValue(filtering.Value, value))
+	case FilterBetween, FilterBetweenExclusive:
+		return betweenTruth(filtering, value)
+	case FilterLike, FilterILike, FilterNotLike, FilterContains, FilterIContains, FilterStartsWith, FilterEndsWith:
+		return m.like(filtering, value)
+	}
+
+	return truthUnknown
+}
+
+func (e *evaluator) compare(sortings []Sorting, a reflect.Value, b reflect.Value) int {
+	for _, sorting := range sortings {
+		f, ok := e.fields[sorting.Field]
+
+		if !ok {
+			continue
+		}
+
+		if c := compareSorted(sorting.Order, f.value(a), f.value(b)); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func (e *evaluator) compareCursor(sortings []Sorting, keys []any, v reflect.Value) int {
+	for i, sorting := range sortings {
+		f, ok := e.fields[sorting.Field]
+
+		if !ok || i >= len(keys) {
+			continue
+		}
+
+		if c := compareSorted(sorting.Order, f.value(v), keys[i]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func compareSorted(order string, a any, b any) int {
+	descending := order == OrderDesc || order == OrderDescNullsFirst || order == OrderDescNullsLast
+
+	if a == nil || b == nil {
+		nullsFirst := order == OrderAscNullsFirst || order == OrderDescNullsFirst || order == OrderDesc
+
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case (a == nil) == nullsFirst:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	c, _ := compareValues(a, b)
+
+	if descending {
+		return -c
+	}
+
+	return c
+}
+
+func equalValues(a any, b any) bool {
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func compareTruth(a any, b any, test func(int) bool) truth {
+	c, ok := compareValues(a, b)
+
+	if !ok {
+		return truthUnknown
+	}
+
+	return toTruth(test(c))
+}
+
+func containsValue(list any, value any) truth {
+	items := reflect.ValueOf(list)
+
+	if items.Kind() != reflect.Slice {
+		return truthUnknown
+	}
+
+	for i := range items.Len() {
+		if equalValues(value, items.Index(i).Interface()) {
+			return truthTrue
+		}
+	}
+
+	return truthFalse
+}
+
+func betweenTruth(filtering Filtering, value any) truth {
+	r, ok := filtering.Value.(Range)
+
+	if !ok {
+		return truthUnknown
+	}
+
+	if filtering.Filter == FilterBetweenExclusive || r.Exclusive {
+		return andTruth(
+			compareTruth(value, r.Lower, func(c int) bool { return c > 0 }),
+			compareTruth(value, r.Upper, func(c int) bool { return c < 0 }),
+		)
+	}
+
+	return andTruth(
+		compareTruth(value, r.Lower, func(c int) bool { return c >= 0 }),
+		compareTruth(value, r.Upper, func(c int) bool { return c <= 0 }),
+	)
+}
+
+func (m matcher) like(filtering Filtering, value any) truth {
+	s := reflect.ValueOf(value)
+
+	if s.Kind() != reflect.String {
+		return truthUnknown
+	}
+
+	pattern, ok := filtering.LikePattern()
+
+	if !ok {
+		return truthUnknown
+	}
+
+	insensitive := filtering.Filter == FilterILike || filtering.Filter == FilterIContains
+	matched := m.likeRegexp(pattern, insensitive).MatchString(s.String())
+
+	if filtering.Filter == FilterNotLike {
+		return toTruth(!matched)
+	}
+
+	return toTruth(matched)
+}
+
+func (m matcher) likeRegexp(pattern string, insensitive bool) *regexp.Regexp {
+	var b strings.Builder
+
+	b.WriteString("(?s)")
+
+	if insensitive {
+		b.WriteString("(?i)")
+	}
+
+	key := b.String() + pattern
+
+	if re, ok := m.likes[key]; ok {
+		return re
+	}
+
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], LikeEscape) && i+len(LikeEscape) < len(pattern):
+			i += len(LikeEscape)
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case pattern[i] == '%':
+			b.WriteString(".*")
+		case pattern[i] == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	b.WriteString("$")
+
+	re := regexp.MustCompile(b.String())
+	m.likes[key] = re
+
+	return re
+}
+
+func toTruth(b bool) truth {
+	if b {
+		return truthTrue
+	}
+
+	return truthFalse
+}
+
+func notTruth(t truth) truth {
+	switch t {
+	case truthTrue:
+		return truthFalse
+	case truthFalse:
+		return truthTrue
+	}
+
+	return truthUnknown
+}
+
+func andTruth(a truth, b truth) truth {
+	switch {
+	case a == truthFalse || b == truthFalse:
+		return truthFalse
+	case a == truthUnknown || b == truthUnknown:
+		return truthUnknown
+	}
+
+	return truthTrue
+}
@@ -0,0 +1,172 @@
+package query_test
+
+import (
+	"database/sql"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	tickets := []exampleTicket{
+		{Id: 1, Status: "open", Priority: 1, Assignee: query.NewNull("jane", true)},
+		{Id: 2, Status: "closed", Priority: 3},
+		{Id: 3, Status: "open", Priority: 5},
+		{Id: 4, Status: "pending_review", Priority: 2, Assignee: query.NewNull("john", true)},
+		{Id: 5, Status: "open", Priority: 3, Assignee: query.NewNull("Jane", true)},
+	}
+	ids := func(items []exampleTicket) []int {
+		result := make([]int, len(items))
+
+		for i, item := range items {
+			result[i] = item.Id
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{name: "empty", query: "", expected: []int{1, 2, 3, 4, 5}},
+		{name: "equals", query: "status=eq:open", expected: []int{1, 3, 5}},
+		{name: "not-equals", query: "status=neq:open", expected: []int{2, 4}},
+		{name: "range", query: "priority=gt:1,lte:3", expected: []int{2, 4, 5}},
+		{name: "between", query: "priority=between:2|3", expected: []int{2, 4, 5}},
+		{name: "in", query: "id=in:1|3|7", expected: []int{1, 3}},
+		{name: "not-in", query: "id=nin:1|3", expected: []int{2, 4, 5}},
+		{name: "is-null", query: "assignee=isnull", expected: []int{2, 3}},
+		{name: "not-null", query: "assignee=notnull", expected: []int{1, 4, 5}},
+		{name: "null-equals", query: "assignee=neq:jane", expected: []int{4, 5}},
+		{name: "like", query: "status=like:%25en", expected: []int{1, 3, 5}},
+		{name: "like-escape", query: `status=like:pending\_%25`, expected: []int{4}},
+		{name: "ilike", query: "assignee=ilike:JANE", expected: []int{1, 5}},
+		{name: "contains", query: "status=contains:_", expected: []int{4}},
+		{name: "starts-with", query: "status=startswith:clo", expected: []int{2}},
+		{name: "where", query: "filter=(status eq 'open' or priority gte 3) and not assignee isnull", expected: []int{1, 5}},
+		{name: "where-unknown", query: "filter=not assignee eq 'jane'", expected: []int{4, 5}},
+		{name: "sort", query: "sort=priority:desc,id:asc", expected: []int{3, 2, 5, 4, 1}},
+		{name: "sort-nulls", query: "sort=assignee:asc,id:desc", expected: []int{5, 1, 4, 3, 2}},
+		{name: "sort-nulls-first", query: "sort=assignee:asc_nulls_first,id:asc", expected: []int{2, 3, 5, 1, 4}},
+		{name: "sort-desc-nulls", query: "sort=assignee:desc,id:asc", expected: []int{2, 3, 4, 1, 5}},
+		{name: "limit", query: "sort=id:desc&limit=2", expected: []int{5, 4}},
+		{name: "offset", query: "sort=id:desc&limit=2&offset=3", expected: []int{2, 1}},
+		{name: "filter-offset", query: "status=eq:open&offset=1", expected: []int{3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			q, err := ticketParser.Parse(values)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, tt.expected, ids(query.Apply(q, tickets)), "applied items should be equal")
+		})
+	}
+
+	t.Run("pointers", func(t *testing.T) {
+		values, _ := url.ParseQuery("status=eq:open&sort=priority:desc")
+		q, _ := ticketParser.Parse(values)
+		items := []*exampleTicket{&tickets[0], &tickets[1], &tickets[2]}
+
+		assert.Equal(t, []*exampleTicket{&tickets[2], &tickets[0]}, query.Apply(q, items), "applied items should be equal")
+	})
+
+	t.Run("seq", func(t *testing.T) {
+		values, _ := url.ParseQuery("status=eq:open&limit=2")
+		q, _ := ticketParser.Parse(values)
+		visited := 0
+		seq := func(yield func(exampleTicket) bool) {
+			for _, ticket := range tickets {
+				visited++
+
+				if !yield(ticket) {
+					return
+				}
+			}
+		}
+
+		assert.Equal(t, []int{1, 3}, ids(slices.Collect(query.ApplySeq(q, seq))), "applied items should be equal")
+		assert.Equal(t, 3, visited, "should stop after the limit")
+	})
+
+	t.Run("unknown-field", func(t *testing.T) {
+		q := query.Query{Filterings: []query.Filtering{{Field: "unknown", Filter: query.FilterEquals, Value: 1}}}
+
+		assert.Empty(t, query.Apply(q, tickets), "unknown fields should not match")
+	})
+}
+
+func TestApplyTime(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	deletedAt := day(3)
+	events := []exampleEvent{
+		{Id: 1, CreatedAt: day(2), DeletedAt: &deletedAt},
+		{Id: 2, CreatedAt: day(1), Priority: sql.NullInt32{Int32: 2, Valid: true}},
+		{Id: 3, CreatedAt: day(4), ArchivedAt: sql.NullTime{Time: day(5), Valid: true}},
+	}
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []exampleEvent
+	}{
+		{name: "time", query: "created_at=gte:2024-01-02&sort=created_at:desc", expected: []exampleEvent{events[2], events[0]}},
+		{name: "pointer", query: "deleted_at=lt:2024-01-04", expected: []exampleEvent{events[0]}},
+		{name: "null-time", query: "archived_at=notnull", expected: []exampleEvent{events[2]}},
+		{name: "null-int", query: "priority=gte:1", expected: []exampleEvent{events[1]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			q, err := eventParser.Parse(values)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, tt.expected, query.Apply(q, events), "applied items should be equal")
+		})
+	}
+}
+
+func TestApplyCursor(t *testing.T) {
+	cursorParser := query.MustParser(query.NewParser[exampleTicket]()).WithCursor([]byte("secret"))
+	tickets := make([]exampleTicket, 0, 6)
+
+	for i := range 6 {
+		tickets = append(tickets, exampleTicket{Id: i + 1, Status: "open", Priority: i % 3})
+	}
+
+	values, _ := url.ParseQuery("sort=priority:desc,id:asc&limit=2")
+	q, _ := cursorParser.Parse(values)
+	first := query.Apply(q, tickets)
+
+	assert.Equal(t, []exampleTicket{tickets[2], tickets[5]}, first, "first page should be equal")
+
+	next, err := cursorParser.NextCursor(q, first[len(first)-1])
+
+	assert.NoError(t, err, "should not return an error")
+
+	values.Set(query.ParamCursor, next)
+	q, _ = cursorParser.Parse(values)
+	second := query.Apply(q, tickets)
+
+	assert.Equal(t, []exampleTicket{tickets[1], tickets[4]}, second, "next page should be equal")
+
+	prev, err := cursorParser.PrevCursor(q, second[0])
+
+	assert.NoError(t, err, "should not return an error")
+
+	values.Set(query.ParamCursor, prev)
+	q, _ = cursorParser.Parse(values)
+
+	assert.Equal(t, first, query.Apply(q, tickets), "previous page should be equal")
+}
@@ -0,0 +1,152 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var (
+	ParamPageSize   = "page[size]"
+	ParamPageLimit  = "page[limit]"
+	ParamPageOffset = "page[offset]"
+
+	ErrInvalidBracket = errors.New("invalid bracket parameter")
+)
+
+type bracketFilter struct {
+	key    string
+	filter string
+	raws   []string
+}
+
+func (p *Parser) parseBracketFilter(values url.Values) (map[string][]bracketFilter, error) {
+	keys := make([]string, 0)
+	parsingError := ParsingError{}
+
+	for key := range values {
+		if strings.HasPrefix(key, ParamFilter+"[") {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	bracketFilters := make(map[string][]bracketFilter, len(keys))
+
+	for _, key := range keys {
+		segments, ok := p.splitBracketKey(key)
+
+		if !ok {
+			parsingError.add(p.strictError(newFieldError(CodeInvalidSyntax, key, "", "", key, ErrInvalidBracket)))
+			continue
+		}
+
+		requested, filter := strings.Join(segments, SeparatorSelector), FilterEquals
+
+		if _, ok := p.lookupField(requested); !ok && len(segments) > 1 {
+			requested, filter = strings.Join(segments[:len(segments)-1], SeparatorSelector), segments[len(segments)-1]
+		}
+
+		if _, ok := p.lookupField(requested); !ok {
+			parsingError.add(p.strictError(newFieldError(CodeUnknownField, key, requested, filter, "", ErrUnknownField)))
+			continue
+		}
+
+		bracketFilters[requested] = append(bracketFilters[requested], bracketFilter{
+			key:    key,
+			filter: filter,
+			raws:   values[key],
+		})
+	}
+
+	return bracketFilters, parsingError.err()
+}
+
+func (p *Parser) appendBracketFilterings(filterings []Filtering, parsingError *ParsingError, f field, requested string, bracketFilters []bracketFilter) []Filtering {
+	for _, bracketFilter := range bracketFilters {
+		if !f.filterable {
+			parsingError.add(p.strictError(newFieldError(CodeNotFilterable, bracketFilter.key, f.name, bracketFilter.filter, "", ErrNotFilterable)))
+			continue
+		}
+
+		for _, raw := range bracketFilter.raws {
+			filter, args := p.splitBracketArgs(f, bracketFilter.filter, strings.TrimSpace(raw))
+			filtering, err := p.newFiltering(bracketFilter.key, f, requested, filter, args)
+
+			if err != nil {
+				if p.strict || !isDroppableFilterError(err) {
+					parsingError.add(err)
+				}
+
+				continue
+			}
+
+			filterings = append(filterings, filtering)
+		}
+	}
+
+	return filterings
+}
+
+func (p *Parser) splitBracketKey(key string) ([]string, bool) {
+	inner, ok := strings.CutPrefix(key, ParamFilter+"[")
+
+	if !ok || !strings.HasSuffix(inner, "]") {
+		return nil, false
+	}
+
+	segments := strings.Split(strings.TrimSuffix(inner, "]"), "][")
+
+	for _, segment := range segments {
+		if len(segment) == 0 || strings.ContainsAny(segment, "[]") {
+			return nil, false
+		}
+	}
+
+	return segments, true
+}
+
+func (p *Parser) splitBracketArgs(f field, filter string, value string) (string, []string) {
+	switch {
+	case slices.Contains(valuelessFilterValues, filter):
+		if strings.EqualFold(value, "false") {
+			if filter == FilterIsNull {
+				return FilterNotNull, nil
+			}
+
+			return FilterIsNull, nil
+		}
+
+		if len(value) == 0 || strings.EqualFold(value, "true") {
+			return filter, nil
+		}
+	case slices.Contains(listFilterValues, filter):
+		return filter, p.splitClean(value, SeparatorField, -1)
+	case f.nullable && value == NullValue && filter == FilterEquals:
+		return FilterIsNull, nil
+	case f.nullable && value == NullValue && filter == FilterNotEquals:
+		return FilterNotNull, nil
+	}
+
+	return filter, []string{value}
+}
+
+func (p *Parser) pageParam(values url.Values, fallback string, params ...string) string {
+	if p.brackets {
+		for _, param := range params {
+			if values.Has(param) {
+				return param
+			}
+		}
+	}
+
+	return fallback
+}
+
+func (p *Parser) WithBracketSyntax(brackets bool) *Parser {
+	p.brackets = brackets
+
+	return p
+}
@@ -0,0 +1,79 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBracketSyntax(t *testing.T) {
+	postParser := query.MustParser(query.NewParser[examplePost]()).WithBracketSyntax(true)
+	bracketTicketParser := query.MustParser(query.NewParser[exampleTicket]()).WithBracketSyntax(true)
+
+	t.Run("same-query", func(t *testing.T) {
+		bracketValues, _ := url.ParseQuery("filter[id][gte]=2&filter[id][lte]=9&filter[author][first_name]=John&sort=-id,title&page[size]=20&page[offset]=40")
+		colonValues, _ := url.ParseQuery("id=gte:2,lte:9&author.first_name=John&sort=id:desc,title&limit=20&offset=40")
+
+		expected, err := query.MustParser(query.NewParser[examplePost]()).Parse(colonValues)
+		assert.NoError(t, err, "should not return an error")
+
+		q, err := postParser.Parse(bracketValues)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q, "query should be equal to the colon syntax")
+	})
+
+	t.Run("nested-operator", func(t *testing.T) {
+		values, _ := url.ParseQuery("filter[author][last_name][startswith]=Do&page[limit]=5")
+		expected := []query.Filtering{
+			{Field: "author.last_name", Filter: query.FilterStartsWith, Value: "Do", Requested: "author.last_name", Column: "author.last_name"},
+		}
+		q, err := postParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+		assert.Equal(t, 5, q.Limit, "limit should be equal")
+	})
+
+	t.Run("lists-and-null", func(t *testing.T) {
+		values, _ := url.ParseQuery("filter[status][in]=open,pending&filter[priority][between]=1,5&filter[assignee][isnull]=false")
+		expected := []query.Filtering{
+			{Field: "status", Filter: query.FilterIn, Value: []string{"open", "pending"}, Requested: "status", Column: "status"},
+			{Field: "priority", Filter: query.FilterBetween, Value: query.Range{Lower: 1, Upper: 5}, Requested: "priority", Column: "priority"},
+			{Field: "assignee", Filter: query.FilterNotNull, Requested: "assignee", Column: "assignee"},
+		}
+		q, err := bracketTicketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		strictParser := query.MustParser(query.NewParser[examplePost]()).WithBracketSyntax(true).WithStrict(true)
+		values, _ := url.ParseQuery("filter[unknown][eq]=1&filter[id][gte=2")
+		_, err := strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		assert.True(t, errors.As(err, &parsingError), "should return a parsing error")
+		assert.Len(t, parsingError.Errors, 2, "should report every invalid parameter")
+
+		q, err := postParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "invalid parameters should be dropped")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		values, _ := url.ParseQuery("filter[id][gte]=2&sort=-id&page[size]=20")
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "bracket filters should be ignored")
+		assert.Empty(t, q.Sortings, "descending shorthand should be ignored")
+		assert.Equal(t, query.DefaultBaseLimit, q.Limit, "page size should be ignored")
+	})
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"cmp"
+	"reflect"
+	"time"
+)
+
+func compareValues(a any, b any) (int, bool) {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+
+		if !ok {
+			return 0, false
+		}
+
+		return at.Compare(bt), true
+	}
+
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+
+	if !av.IsValid() || !bv.IsValid() || av.Kind() != bv.Kind() {
+		return 0, false
+	}
+
+	switch av.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(av.Int(), bv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(av.Uint(), bv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(av.Float(), bv.Float()), true
+	case reflect.String:
+		return cmp.Compare(av.String(), bv.String()), true
+	case reflect.Bool:
+		return compareBools(av.Bool(), bv.Bool()), true
+	}
+
+	return 0, false
+}
+
+func compareBools(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
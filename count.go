@@ -0,0 +1,56 @@
+package query
+
+import (
+	"strings"
+)
+
+var (
+	ParamCount = "count"
+
+	countValues = map[string]CountMode{
+		"none":      CountNone,
+		"false":     CountNone,
+		"exact":     CountExact,
+		"true":      CountExact,
+		"estimated": CountEstimated,
+		"only":      CountOnly,
+	}
+)
+
+func (p *Parser) parseCount(raw string) (CountMode, error) {
+	raw = strings.TrimSpace(raw)
+
+	if len(raw) == 0 {
+		return CountNone, nil
+	}
+
+	count, ok := countValues[strings.ToLower(raw)]
+
+	if !ok {
+		return CountNone, p.strictError(newFieldError(CodeInvalidValue, ParamCount, "", "", raw, ErrInvalidCount))
+	}
+
+	return p.allowCount(ParamCount, raw, count)
+}
+
+func (p *Parser) allowCount(param string, raw string, count CountMode) (CountMode, error) {
+	if count != CountNone && p.countDisabled {
+		return CountNone, newFieldError(CodeCountDisabled, param, "", "", raw, ErrCountDisabled)
+	}
+
+	return count, nil
+}
+
+func (q Query) ForCount() Query {
+	return Query{
+		Filterings: q.Filterings,
+		Where:      q.Where,
+		Count:      q.Count,
+	}
+}
+
+func (p *Parser) WithCount(enabled bool) *Parser {
+	p.countDisabled = !enabled
+
+	return p
+}
@@ -0,0 +1,75 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected query.CountMode
+	}{
+		{value: "", expected: query.CountNone},
+		{value: "none", expected: query.CountNone},
+		{value: "false", expected: query.CountNone},
+		{value: "true", expected: query.CountExact},
+		{value: "exact", expected: query.CountExact},
+		{value: "estimated", expected: query.CountEstimated},
+		{value: "ONLY", expected: query.CountOnly},
+		{value: "maybe", expected: query.CountNone},
+	}
+
+	for _, test := range tests {
+		t.Run("mode-"+test.value, func(t *testing.T) {
+			q, err := parser.Parse(url.Values{query.ParamCount: {test.value}})
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, test.expected, q.Count, "count should be equal")
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		strictParser := query.MustParser(query.NewParser[examplePost]()).WithStrict(true)
+		_, err := strictParser.Parse(url.Values{query.ParamCount: {"maybe"}})
+
+		assert.ErrorIs(t, err, query.ErrInvalidCount, "should reject unknown modes")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabledParser := query.MustParser(query.NewParser[examplePost]()).WithCount(false)
+		_, err := disabledParser.Parse(url.Values{query.ParamCount: {"only"}})
+
+		var fieldError query.FieldError
+
+		assert.True(t, errors.As(err, &fieldError), "should return a field error")
+		assert.Equal(t, query.CodeCountDisabled, fieldError.Code, "code should be equal")
+		assert.Equal(t, query.ParamCount, fieldError.Param, "param should be equal")
+
+		q, err := disabledParser.Parse(url.Values{query.ParamCount: {"none"}})
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.CountNone, q.Count, "count should be equal")
+
+		_, err = disabledParser.WithOData(true).Parse(url.Values{query.ParamODataCount: {"true"}})
+
+		assert.ErrorIs(t, err, query.ErrCountDisabled, "should reject $count")
+	})
+
+	t.Run("for-count", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gt:1&sort=id:desc&limit=5&offset=10&select=title&count=only")
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.Query{
+			Filterings: []query.Filtering{
+				{Field: "id", Filter: query.FilterGreaterThan, Value: 1, Requested: "id", Column: "id"},
+			},
+			Count: query.CountOnly,
+		}, q.ForCount(), "count query should only keep the filters")
+	})
+}
@@ -0,0 +1,247 @@
+package query
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ParamCursor = "cursor"
+
+	SeparatorCursor = "."
+
+	ErrCursorDisabled = errors.New("cursor pagination is not enabled")
+	ErrCursorItem     = errors.New("item does not match the parser type")
+)
+
+type CursorDirection string
+
+const (
+	CursorNext CursorDirection = "next"
+	CursorPrev CursorDirection = "prev"
+)
+
+type Cursor struct {
+	Keys      []any
+	Direction CursorDirection
+}
+
+type cursorPayload struct {
+	Keys      []json.RawMessage `json:"k"`
+	Sort      string            `json:"s"`
+	Filter    string            `json:"f"`
+	Direction CursorDirection   `json:"d"`
+}
+
+type cursorFilter struct {
+	Filterings []Filtering `json:"f,omitempty"`
+	Where      Expr        `json:"w,omitempty"`
+}
+
+func (p *Parser) parseCursor(raw string, sortings []Sorting, filterings []Filtering, where Expr) (*Cursor, error) {
+	if len(p.cursorSecret) == 0 || len(raw) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := p.decodeCursor(raw, sortings, filterings, where)
+
+	if err != nil {
+		return nil, newFieldError(CodeInvalidCursor, ParamCursor, "", "", raw, err)
+	}
+
+	return cursor, nil
+}
+
+func (p *Parser) decodeCursor(raw string, sortings []Sorting, filterings []Filtering, where Expr) (*Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(raw, SeparatorCursor)
+
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+
+	if err != nil || !hmac.Equal(signature, p.signCursor(encodedPayload)) {
+		return nil, ErrCursorSignature
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if payload.Direction != CursorNext && payload.Direction != CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+
+	if len(sortings) == 0 {
+		return nil, ErrCursorWithoutSort
+	}
+
+	if payload.Sort != sortSignature(sortings) || len(payload.Keys) != len(sortings) {
+		return nil, ErrCursorSort
+	}
+
+	filter, err := filterSignature(filterings, where)
+
+	if err != nil || payload.Filter != filter {
+		return nil, ErrCursorFilter
+	}
+
+	keys := make([]any, len(sortings))
+
+	for i, sorting := range sortings {
+		f, ok := p.lookupField(sorting.Field)
+
+		if !ok {
+			return nil, ErrCursorSort
+		}
+
+		if keys[i], err = decodeCursorKey(f, payload.Keys[i]); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return &Cursor{
+		Keys:      keys,
+		Direction: payload.Direction,
+	}, nil
+}
+
+func (p *Parser) NextCursor(q Query, item any) (string, error) {
+	return p.encodeCursor(q, item, CursorNext)
+}
+
+func (p *Parser) PrevCursor(q Query, item any) (string, error) {
+	return p.encodeCursor(q, item, CursorPrev)
+}
+
+func (p *Parser) encodeCursor(q Query, item any, direction CursorDirection) (string, error) {
+	if len(p.cursorSecret) == 0 {
+		return "", ErrCursorDisabled
+	}
+
+	if len(q.Sortings) == 0 {
+		return "", ErrCursorWithoutSort
+	}
+
+	v := reflect.ValueOf(item)
+
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || v.Type() != p.typ {
+		return "", fmt.Errorf("%w: got %T", ErrCursorItem, item)
+	}
+
+	keys := make([]json.RawMessage, len(q.Sortings))
+
+	for i, sorting := range q.Sortings {
+		f, ok := p.lookupField(sorting.Field)
+
+		if !ok {
+			return "", fmt.Errorf("sort field %q: %w", sorting.Field, ErrUnknownField)
+		}
+
+		key, err := json.Marshal(f.value(v))
+
+		if err != nil {
+			return "", fmt.Errorf("sort field %q: %w", sorting.Field, err)
+		}
+
+		keys[i] = key
+	}
+
+	filter, err := filterSignature(q.Filterings, q.Where)
+
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(cursorPayload{
+		Keys:      keys,
+		Sort:      sortSignature(q.Sortings),
+		Filter:    filter,
+		Direction: direction,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(data)
+	encodedSignature := base64.RawURLEncoding.EncodeToString(p.signCursor(encodedPayload))
+
+	return encodedPayload + SeparatorCursor + encodedSignature, nil
+}
+
+func (p *Parser) signCursor(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, p.cursorSecret)
+	mac.Write([]byte(encodedPayload))
+
+	return mac.Sum(nil)
+}
+
+func decodeCursorKey(f field, raw json.RawMessage) (any, error) {
+	if bytes.Equal(raw, jsonNull) {
+		if !f.nullable {
+			return nil, ErrInvalidCursor
+		}
+
+		return nil, nil
+	}
+
+	key := reflect.New(f.typ)
+
+	if err := json.Unmarshal(raw, key.Interface()); err != nil {
+		return nil, err
+	}
+
+	return key.Elem().Interface(), nil
+}
+
+func sortSignature(sortings []Sorting) string {
+	parts := make([]string, len(sortings))
+
+	for i, sorting := range sortings {
+		parts[i] = sorting.Field + SeparatorFilter + sorting.Order
+	}
+
+	return strings.Join(parts, SeparatorField)
+}
+
+func filterSignature(filterings []Filtering, where Expr) (string, error) {
+	data, err := json.Marshal(cursorFilter{
+		Filterings: filterings,
+		Where:      where,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+func (p *Parser) WithCursor(secret []byte) *Parser {
+	p.cursorSecret = secret
+
+	return p
+}
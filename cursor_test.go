@@ -0,0 +1,116 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	cursorParser := query.MustParser(query.NewParser[exampleEvent]()).WithCursor([]byte("secret"))
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	event := exampleEvent{Id: 7, CreatedAt: createdAt}
+
+	t.Run("round-trip", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=created_at:desc,note:asc_nulls_first,id&priority=gte:1&offset=30")
+		q, err := cursorParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Nil(t, q.Cursor, "cursor should be nil without a token")
+
+		next, err := cursorParser.NextCursor(q, &event)
+
+		assert.NoError(t, err, "should not return an error")
+
+		values.Set(query.ParamCursor, next)
+		q, err = cursorParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, &query.Cursor{Keys: []any{createdAt, nil, 7}, Direction: query.CursorNext}, q.Cursor, "cursor should be equal")
+		assert.Equal(t, 0, q.Offset, "cursor should reset the offset")
+
+		prev, err := cursorParser.PrevCursor(q, event)
+
+		assert.NoError(t, err, "should not return an error")
+
+		values.Set(query.ParamCursor, prev)
+		q, err = cursorParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.CursorPrev, q.Cursor.Direction, "direction should be equal")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=id:desc&priority=gte:1")
+		q, _ := cursorParser.Parse(values)
+		cursor, err := cursorParser.NextCursor(q, event)
+
+		assert.NoError(t, err, "should not return an error")
+
+		tests := []struct {
+			name  string
+			query string
+			err   error
+		}{
+			{name: "sort", query: "sort=id:asc&priority=gte:1", err: query.ErrCursorSort},
+			{name: "filter", query: "sort=id:desc&priority=gte:2", err: query.ErrCursorFilter},
+			{name: "without-sort", query: "priority=gte:1", err: query.ErrCursorWithoutSort},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				values, _ := url.ParseQuery(test.query)
+				values.Set(query.ParamCursor, cursor)
+
+				_, err := cursorParser.Parse(values)
+
+				var fieldError query.FieldError
+
+				assert.True(t, errors.As(err, &fieldError), "should return a field error")
+				assert.Equal(t, query.CodeInvalidCursor, fieldError.Code, "code should be equal")
+				assert.ErrorIs(t, err, test.err, "error should be equal")
+			})
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=id")
+		q, _ := cursorParser.Parse(values)
+		cursor, _ := cursorParser.NextCursor(q, event)
+		otherParser := query.MustParser(query.NewParser[exampleEvent]()).WithCursor([]byte("other"))
+
+		values.Set(query.ParamCursor, cursor)
+		_, err := otherParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrCursorSignature, "should reject a foreign signature")
+
+		values.Set(query.ParamCursor, "garbage")
+		_, err = cursorParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidCursor, "should reject a malformed cursor")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		eventParser := query.MustParser(query.NewParser[exampleEvent]())
+		values, _ := url.ParseQuery("sort=id&cursor=abc")
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Nil(t, q.Cursor, "cursor should be ignored")
+
+		_, err = eventParser.NextCursor(q, event)
+
+		assert.ErrorIs(t, err, query.ErrCursorDisabled, "should not mint cursors")
+	})
+
+	t.Run("wrong-item", func(t *testing.T) {
+		q, _ := cursorParser.Parse(url.Values{query.ParamSort: {"id"}})
+		_, err := cursorParser.NextCursor(q, examplePost{})
+
+		assert.ErrorIs(t, err, query.ErrCursorItem, "should reject other types")
+	})
+}
@@ -1,6 +1,142 @@
 package query
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrUnknownField        = errors.New("unknown field")
+	ErrNotFilterable       = errors.New("field is not filterable")
+	ErrNotSortable         = errors.New("field is not sortable")
+	ErrNotSelectable       = errors.New("field is not selectable")
+	ErrUnknownOperator     = errors.New("unknown filter operator")
+	ErrUnsupportedOperator = errors.New("filter operator not supported by field")
+	ErrUnexpectedValue     = errors.New("filter operator does not take a value")
+	ErrMissingValue        = errors.New("filter operator requires a value")
+	ErrUnexpectedList      = errors.New("filter operator does not take a list")
+	ErrEmptyList           = errors.New("list is empty")
+	ErrEmptyCondition      = errors.New("filter condition is empty")
+	ErrListTooLong         = errors.New("list is too long")
+	ErrInvalidRange        = errors.New("invalid range")
+	ErrUnknownOrder        = errors.New("unknown sort order")
+	ErrUnsupportedOrder    = errors.New("sort order not supported by field")
+	ErrInvalidLimit        = errors.New("invalid limit")
+	ErrLimitExceeded       = errors.New("limit exceeded")
+	ErrInvalidOffset       = errors.New("invalid offset")
+	ErrInvalidCount        = errors.New("invalid count")
+	ErrCountDisabled       = errors.New("counting is disabled")
+	ErrInvalidCursor       = errors.New("cursor is malformed")
+	ErrCursorSignature     = errors.New("cursor signature is invalid")
+	ErrCursorSort          = errors.New("cursor does not match the sort")
+	ErrCursorFilter        = errors.New("cursor does not match the filters")
+	ErrCursorWithoutSort   = errors.New("cursor requires a sort")
+)
+
+const (
+	CodeInvalidValue        = "invalid_value"
+	CodeUnknownField        = "unknown_field"
+	CodeNotFilterable       = "not_filterable"
+	CodeNotSortable         = "not_sortable"
+	CodeNotSelectable       = "not_selectable"
+	CodeUnknownOperator     = "unknown_operator"
+	CodeUnsupportedOperator = "unsupported_operator"
+	CodeUnknownOrder        = "unknown_order"
+	CodeUnsupportedOrder    = "unsupported_order"
+	CodeLimitExceeded       = "limit_exceeded"
+	CodeListTooLong         = "list_too_long"
+	CodeInvalidSyntax       = "invalid_syntax"
+	CodeInvalidCursor       = "invalid_cursor"
+	CodeCountDisabled       = "count_disabled"
+)
+
+type FieldError struct {
+	Param    string
+	Field    string
+	Operator string
+	Value    string
+	Code     string
+	Message  string
+	Err      error
+}
+
+func newFieldError(code string, param string, field string, operator string, value string, err error) FieldError {
+	e := FieldError{
+		Param:    param,
+		Field:    field,
+		Operator: operator,
+		Value:    value,
+		Code:     code,
+		Err:      err,
+	}
+
+	subject := field
+
+	if len(subject) == 0 {
+		subject = param
+	}
+
+	switch code {
+	case CodeUnknownField:
+		e.Message = fmt.Sprintf("unknown field %q", field)
+	case CodeInvalidSyntax:
+		e.Message = fmt.Sprintf("invalid expression %q", value)
+	case CodeNotFilterable:
+		e.Message = fmt.Sprintf("field %q is not filterable", subject)
+	case CodeNotSortable:
+		e.Message = fmt.Sprintf("field %q is not sortable", field)
+	case CodeNotSelectable:
+		e.Message = fmt.Sprintf("field %q is not selectable", field)
+	case CodeUnknownOperator:
+		e.Message = fmt.Sprintf("unknown filter operator %q for %q", operator, subject)
+	case CodeUnsupportedOperator:
+		e.Message = fmt.Sprintf("filter operator %q is not supported by field %q", operator, subject)
+	case CodeUnknownOrder:
+		e.Message = fmt.Sprintf("unknown sort order %q for %q", value, subject)
+	case CodeUnsupportedOrder:
+		e.Message = fmt.Sprintf("sort order %q is not supported by non-nullable field %q", value, subject)
+	case CodeInvalidCursor:
+		e.Message = "invalid cursor"
+	case CodeCountDisabled:
+		e.Message = fmt.Sprintf("counting is disabled for %q", subject)
+	default:
+		e.Message = fmt.Sprintf("invalid value %q for %q", value, subject)
+	}
+
+	if reason := errorReason(err); len(reason) > 0 {
+		e.Message += ": " + reason
+	}
+
+	return e
+}
+
+func (e FieldError) Error() string {
+	return e.Param + ": " + e.Message
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+func errorReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var numError *strconv.NumError
+
+	if errors.As(err, &numError) {
+		return numError.Err.Error()
+	}
+
+	switch err {
+	case ErrUnknownField, ErrNotFilterable, ErrNotSortable, ErrNotSelectable, ErrUnknownOperator, ErrUnsupportedOperator, ErrUnknownOrder, ErrUnsupportedOrder, ErrInvalidLimit, ErrInvalidOffset, ErrCountDisabled:
+		return ""
+	}
+
+	return err.Error()
+}
 
 type ParsingError struct {
 	Errors []error
@@ -9,3 +145,28 @@ type ParsingError struct {
 func (e ParsingError) Error() string {
 	return errors.Join(e.Errors...).Error()
 }
+
+func (e ParsingError) Unwrap() []error {
+	return e.Errors
+}
+
+func (e *ParsingError) add(err error) {
+	if err == nil {
+		return
+	}
+
+	if parsingError, ok := err.(ParsingError); ok {
+		e.Errors = append(e.Errors, parsingError.Errors...)
+		return
+	}
+
+	e.Errors = append(e.Errors, err)
+}
+
+func (e ParsingError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
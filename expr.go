@@ -0,0 +1,95 @@
+package query
+
+import (
+	"errors"
+	"strings"
+)
+
+type Expr interface {
+	expr()
+}
+
+type AndExpr struct {
+	Exprs []Expr
+}
+
+type OrExpr struct {
+	Exprs []Expr
+}
+
+type NotExpr struct {
+	Expr Expr
+}
+
+func (Filtering) expr() {}
+func (AndExpr) expr()   {}
+func (OrExpr) expr()    {}
+func (NotExpr) expr()   {}
+
+func newAndExpr(exprs []Expr) Expr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+
+	return AndExpr{Exprs: exprs}
+}
+
+func newOrExpr(exprs []Expr) Expr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+
+	return OrExpr{Exprs: exprs}
+}
+
+func (p *Parser) newExprFiltering(param string, requested string, filter string, args []string, literal bool) (Filtering, error) {
+	f, ok := p.lookupField(requested)
+
+	if !ok {
+		return Filtering{}, newFieldError(CodeUnknownField, param, requested, filter, "", ErrUnknownField)
+	}
+
+	if literal && f.nullable && len(args) == 1 && args[0] == NullValue {
+		switch filter {
+		case FilterEquals:
+			filter, args = FilterIsNull, nil
+		case FilterNotEquals:
+			filter, args = FilterNotNull, nil
+		}
+	}
+
+	return p.newFiltering(param, f, requested, filter, args)
+}
+
+func (p *Parser) wildcardFilter(requested string, filter string, value string) (string, string) {
+	f, ok := p.lookupField(requested)
+
+	if !ok || !strings.Contains(value, "*") || !f.allowsFilter(FilterLike) {
+		return filter, value
+	}
+
+	parts := strings.Split(value, "*")
+
+	for i, part := range parts {
+		parts[i] = EscapeLike(part)
+	}
+
+	switch filter {
+	case FilterEquals:
+		return FilterLike, strings.Join(parts, "%")
+	case FilterNotEquals:
+		return FilterNotLike, strings.Join(parts, "%")
+	}
+
+	return filter, value
+}
+
+func wrapSyntaxError(param string, raw string, err error) error {
+	var syntaxError SyntaxError
+
+	if errors.As(err, &syntaxError) {
+		return newFieldError(CodeInvalidSyntax, param, "", "", raw, syntaxError)
+	}
+
+	return err
+}
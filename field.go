@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 type ParseFunc func(v string) (any, error)
@@ -12,53 +14,232 @@ type ParseFunc func(v string) (any, error)
 type ValueFunc func(any) any
 
 type field struct {
-	name      string
-	parseFunc ParseFunc
-	valueFunc ValueFunc
+	name       string
+	aliases    []string
+	column     string
+	index      []int
+	typ        reflect.Type
+	nullable   bool
+	filters    []string
+	filterable bool
+	sortable   bool
+	selectable bool
+	parseFunc  ParseFunc
+	join       fieldJoin
 }
 
-func newField(name string, kind reflect.Kind) field {
-	var parseFunc ParseFunc
+type fieldJoin struct {
+	table      string
+	localKey   string
+	foreignKey string
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	nullPkgPath = reflect.TypeFor[Null[any]]().PkgPath()
+)
 
-	switch kind {
+func newField(name string, typ reflect.Type, nullable bool) field {
+	return field{
+		name:       name,
+		column:     name,
+		typ:        typ,
+		nullable:   nullable,
+		filters:    defaultFilters(typ, nullable),
+		filterable: isLeafType(typ),
+		sortable:   isScalarType(typ),
+		selectable: true,
+		parseFunc:  newParseFunc(typ),
+	}
+}
+
+func newParseFunc(typ reflect.Type) ParseFunc {
+	if typ == timeType {
+		return ParseTime(DefaultTimeLayouts...)
+	}
+
+	switch typ.Kind() {
 	case reflect.Bool:
-		parseFunc = ParseBool
+		return ParseBool
 	case reflect.Int:
-		parseFunc = ParseInt(0, 0)
+		return ParseInt(0, 0)
 	case reflect.Int8:
-		parseFunc = ParseInt(0, 8)
+		return ParseInt(0, 8)
 	case reflect.Int16:
-		parseFunc = ParseInt(0, 16)
+		return ParseInt(0, 16)
 	case reflect.Int32:
-		parseFunc = ParseInt(0, 32)
+		return ParseInt(0, 32)
 	case reflect.Int64:
-		parseFunc = ParseInt(0, 64)
+		return ParseInt(0, 64)
 	case reflect.Uint:
-		parseFunc = ParseUint(0, 0)
+		return ParseUint(0, 0)
 	case reflect.Uint8:
-		parseFunc = ParseUint(0, 8)
+		return ParseUint(0, 8)
 	case reflect.Uint16:
-		parseFunc = ParseUint(0, 16)
+		return ParseUint(0, 16)
 	case reflect.Uint32:
-		parseFunc = ParseUint(0, 32)
+		return ParseUint(0, 32)
 	case reflect.Uint64:
-		parseFunc = ParseUint(0, 64)
+		return ParseUint(0, 64)
 	case reflect.Float32:
-		parseFunc = ParseFloat(32)
+		return ParseFloat(32)
 	case reflect.Float64:
-		parseFunc = ParseFloat(64)
+		return ParseFloat(64)
 	default:
-		parseFunc = ParseString
+		return ParseString
 	}
+}
 
-	return field{
-		name:      name,
-		parseFunc: parseFunc,
+func defaultFilters(typ reflect.Type, nullable bool) []string {
+	var filters []string
+
+	switch {
+	case !isLeafType(typ):
+		return nil
+	case typ.Kind() == reflect.String:
+		filters = slices.Concat(orderedFilterValues, stringFilterValues)
+	case typ.Kind() == reflect.Bool:
+		filters = slices.Clone(equalityFilterValues)
+	case isScalarType(typ):
+		filters = slices.Clone(orderedFilterValues)
+	default:
+		filters = []string{FilterEquals, FilterNotEquals}
+	}
+
+	if nullable {
+		filters = append(filters, valuelessFilterValues...)
+	}
+
+	return filters
+}
+
+func (f field) names() []string {
+	return append([]string{f.name}, f.aliases...)
+}
+
+func (f field) withParent(parent field) field {
+	aliases := make([]string, 0, (len(parent.aliases)+1)*(len(f.aliases)+1)-1)
+
+	for _, parentName := range parent.names() {
+		for _, name := range f.names() {
+			if parentName != parent.name || name != f.name {
+				aliases = append(aliases, parentName+SeparatorSelector+name)
+			}
+		}
 	}
+
+	f.name = parent.name + SeparatorSelector + f.name
+	f.aliases = aliases
+	f.column = parent.column + SeparatorSelector + f.column
+	f.index = slices.Concat(parent.index, f.index)
+
+	return f
+}
+
+func (f field) value(v reflect.Value) any {
+	for _, i := range f.index {
+		v = unwrapValue(v)
+
+		if !v.IsValid() {
+			return nil
+		}
+
+		v = v.Field(i)
+	}
+
+	if v = unwrapValue(v); !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+func (f field) allowsFilter(filter string) bool {
+	return slices.Contains(f.filters, filter)
+}
+
+func isLeafType(typ reflect.Type) bool {
+	return typ.Kind() != reflect.Struct || typ == timeType
+}
+
+func isScalarType(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+func isNullType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || typ.NumField() != 2 {
+		return false
+	}
+
+	if typ.PkgPath() != nullPkgPath && typ.PkgPath() != "database/sql" {
+		return false
+	}
+
+	valid := typ.Field(1)
+
+	return strings.HasPrefix(typ.Name(), "Null") && valid.Name == "Valid" && valid.Type.Kind() == reflect.Bool
+}
+
+func unwrapFieldType(typ reflect.Type) (reflect.Type, bool) {
+	nullable := false
+
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+		nullable = true
+	}
+
+	if !isNullType(typ) {
+		return typ, nullable
+	}
+
+	typ = typ.Field(0).Type
+
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ, true
+}
+
+func unwrapValue(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		switch {
+		case v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface:
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+
+			v = v.Elem()
+		case isNullType(v.Type()):
+			if !v.Field(1).Bool() {
+				return reflect.Value{}
+			}
+
+			v = v.Field(0)
+		default:
+			return v
+		}
+	}
+
+	return v
 }
 
 var (
-	ErrNoStruct = errors.New("expected struct as generic type")
+	ErrNoStruct       = errors.New("expected struct as generic type")
+	ErrInvalidTag     = errors.New("invalid query tag")
+	ErrDuplicateField = errors.New("duplicate field name")
 )
 
 func getFieldsFromStruct[S any]() ([]field, error) {
@@ -71,45 +252,64 @@ func getFieldsFromStruct[S any]() ([]field, error) {
 		return nil, fmt.Errorf("type %q is not a struct: %w", kind, ErrNoStruct)
 	}
 
-	return getFieldsFromReflectStruct(structType)
+	fields, err := getFieldsFromReflectStruct(structType, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]struct{}, len(fields))
+
+	for _, f := range fields {
+		for _, name := range f.names() {
+			if _, ok := names[name]; ok {
+				return nil, fmt.Errorf("field %q: %w", name, ErrDuplicateField)
+			}
+
+			names[name] = struct{}{}
+		}
+	}
+
+	return fields, nil
 }
 
-func getFieldsFromReflectStruct(st reflect.Type) ([]field, error) {
+func getFieldsFromReflectStruct(st reflect.Type, nullableParent bool) ([]field, error) {
 	fields := make([]field, 0, st.NumField())
 
 	for i := 0; i < st.NumField(); i++ {
 		structField := st.Field(i)
 		name := getFieldNameFromStructField(structField)
+		tag := getFieldTagFromStructField(structField)
 
-		if len(name) == 0 {
+		if len(name) == 0 || tag.skip() {
 			continue
 		}
 
-		structFieldType := structField.Type
+		structFieldType, nullable := unwrapFieldType(structField.Type)
+		nullable = nullable || nullableParent
 
-		if structFieldType.Kind() == reflect.Pointer {
-			structFieldType = structFieldType.Elem()
+		f := newField(name, structFieldType, nullable)
+		f.index = []int{i}
+		f, err := tag.apply(f)
+
+		if err != nil {
+			return nil, err
 		}
 
-		if structFieldType.Kind() != reflect.Struct {
-			fields = append(fields, newField(name, structFieldType.Kind()))
+		fields = append(fields, f)
+
+		if isLeafType(structFieldType) {
 			continue
 		}
 
-		childFields, err := getFieldsFromReflectStruct(structFieldType)
+		childFields, err := getFieldsFromReflectStruct(structFieldType, nullable)
 
 		if err != nil {
 			return nil, err
 		}
 
-		fields = append(fields, newField(name, structFieldType.Kind()))
-
 		for _, child := range childFields {
-			fields = append(fields, field{
-				name:      name + SeparatorSelector + child.name,
-				parseFunc: child.parseFunc,
-				valueFunc: child.valueFunc,
-			})
+			fields = append(fields, child.withParent(f))
 		}
 	}
 
@@ -1,8 +1,10 @@
 package query
 
 import (
+	"database/sql"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -65,6 +67,74 @@ func TestGetFieldsFromStruct(t *testing.T) {
 	})
 }
 
+func TestGetFieldsFromStructTime(t *testing.T) {
+	type example struct {
+		Id        int        `json:"id"`
+		CreatedAt time.Time  `json:"created_at"`
+		DeletedAt *time.Time `json:"deleted_at"`
+	}
+
+	fields, err := getFieldsFromStruct[example]()
+
+	assert.NoError(t, err, "unexpected error")
+
+	names := make([]string, len(fields))
+
+	for i, field := range fields {
+		names[i] = field.name
+	}
+
+	assert.ElementsMatch(t, []string{"id", "created_at", "deleted_at"}, names, "time fields should be leaves")
+
+	for _, field := range fields[1:] {
+		value, err := field.parseFunc("2024-01-02T03:04:05Z")
+
+		assert.NoError(t, err, "should parse RFC 3339")
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), value)
+	}
+}
+
+func TestGetFieldsFromStructNull(t *testing.T) {
+	type example struct {
+		Id     int               `json:"id"`
+		Name   Null[string]      `json:"name"`
+		Age    *Null[int]        `json:"age"`
+		Email  sql.NullString    `json:"email"`
+		Score  sql.Null[float64] `json:"score"`
+		Parent *struct {
+			Id int `json:"id"`
+		} `json:"parent"`
+	}
+
+	fields, err := getFieldsFromStruct[example]()
+
+	assert.NoError(t, err, "unexpected error")
+
+	nullables := make(map[string]bool, len(fields))
+	kinds := make(map[string]reflect.Kind, len(fields))
+
+	for _, field := range fields {
+		nullables[field.name] = field.nullable
+		kinds[field.name] = field.typ.Kind()
+	}
+
+	assert.Equal(t, map[string]bool{
+		"id":        false,
+		"name":      true,
+		"age":       true,
+		"email":     true,
+		"score":     true,
+		"parent":    true,
+		"parent.id": true,
+	}, nullables, "unexpected nullables")
+
+	assert.Equal(t, reflect.String, kinds["name"])
+	assert.Equal(t, reflect.Int, kinds["age"])
+	assert.Equal(t, reflect.String, kinds["email"])
+	assert.Equal(t, reflect.Float64, kinds["score"])
+
+}
+
 func TestGetFieldNameFromStructField(t *testing.T) {
 	t.Run("tagless", func(t *testing.T) {
 		type example struct {
@@ -134,3 +204,144 @@ func TestGetFieldNameFromStructField(t *testing.T) {
 		assert.Equal(t, "user_name", name)
 	})
 }
+
+func TestGetFieldsFromStructTag(t *testing.T) {
+	t.Run("options", func(t *testing.T) {
+		type example struct {
+			Id       int    `json:"id" query:"ops=eq,in"`
+			Secret   string `json:"secret" query:"-"`
+			Name     string `json:"name" query:"sort=false;select=false"`
+			Internal string `json:"internal" query:"filter=false"`
+			Active   bool   `json:"active"`
+		}
+
+		fields, err := getFieldsFromStruct[example]()
+
+		if !assert.NoError(t, err, "unexpected error") {
+			return
+		}
+
+		byName := make(map[string]field, len(fields))
+
+		for _, field := range fields {
+			byName[field.name] = field
+		}
+
+		assert.NotContains(t, byName, "secret", "skipped field should not exist")
+		assert.Equal(t, []string{FilterEquals, FilterIn}, byName["id"].filters)
+		assert.False(t, byName["name"].sortable, "name should not be sortable")
+		assert.False(t, byName["name"].selectable, "name should not be selectable")
+		assert.True(t, byName["name"].filterable, "name should be filterable")
+		assert.False(t, byName["internal"].filterable, "internal should not be filterable")
+		assert.Equal(t, equalityFilterValues, byName["active"].filters, "bool should only allow equality")
+	})
+
+	t.Run("parent", func(t *testing.T) {
+		type child struct {
+			Id int `json:"id"`
+		}
+
+		type example struct {
+			Child child `json:"child"`
+		}
+
+		fields, err := getFieldsFromStruct[example]()
+
+		if !assert.NoError(t, err, "unexpected error") {
+			return
+		}
+
+		assert.Equal(t, "child", fields[0].name)
+		assert.False(t, fields[0].filterable, "struct should not be filterable")
+		assert.False(t, fields[0].sortable, "struct should not be sortable")
+		assert.True(t, fields[0].selectable, "struct should be selectable")
+	})
+
+	t.Run("unsupported-operator", func(t *testing.T) {
+		type example struct {
+			Id int `json:"id" query:"ops=eq,like"`
+		}
+
+		_, err := getFieldsFromStruct[example]()
+
+		assert.ErrorIs(t, err, ErrInvalidTag, "like should not be allowed on int")
+	})
+
+	t.Run("unknown-option", func(t *testing.T) {
+		type example struct {
+			Id int `json:"id" query:"foo=bar"`
+		}
+
+		_, err := getFieldsFromStruct[example]()
+
+		assert.ErrorIs(t, err, ErrInvalidTag, "unknown option should be rejected")
+	})
+
+	t.Run("invalid-bool", func(t *testing.T) {
+		type example struct {
+			Id int `json:"id" query:"sort=maybe"`
+		}
+
+		_, err := getFieldsFromStruct[example]()
+
+		assert.ErrorIs(t, err, ErrInvalidTag, "invalid bool should be rejected")
+	})
+
+	t.Run("declared-order", func(t *testing.T) {
+		type example struct {
+			Id int `json:"id" query:"name=key;sort=maybe;foo=bar;filter=maybe"`
+		}
+
+		for range 20 {
+			_, err := getFieldsFromStruct[example]()
+
+			assert.EqualError(t, err, `field "key": invalid query tag: strconv.ParseBool: parsing "maybe": invalid syntax`, "first invalid option should be reported")
+		}
+	})
+
+	t.Run("join", func(t *testing.T) {
+		type company struct {
+			Id int `json:"id"`
+		}
+
+		type user struct {
+			Id      int     `json:"id"`
+			Company company `json:"company" query:"join=companies;foreign_key=uuid"`
+		}
+
+		type example struct {
+			Author user `json:"author" query:"join=users;column=writer;local_key=writer_id"`
+		}
+
+		fields, err := getFieldsFromStruct[example]()
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, fieldJoin{table: "users", localKey: "writer_id", foreignKey: "id"}, fields[0].join, "join should be equal")
+		assert.Equal(t, "writer.company", fields[2].column, "nested column should be equal")
+		assert.Equal(t, fieldJoin{table: "companies", localKey: "company_id", foreignKey: "uuid"}, fields[2].join, "nested join should be equal")
+	})
+
+	t.Run("join-on-leaf", func(t *testing.T) {
+		type example struct {
+			Id int `json:"id" query:"join=ids"`
+		}
+
+		_, err := getFieldsFromStruct[example]()
+
+		assert.ErrorIs(t, err, ErrInvalidTag, "join should require a struct")
+	})
+
+	t.Run("join-keys-without-table", func(t *testing.T) {
+		type user struct {
+			Id int `json:"id"`
+		}
+
+		type example struct {
+			Author user `json:"author" query:"local_key=author_id"`
+		}
+
+		_, err := getFieldsFromStruct[example]()
+
+		assert.ErrorIs(t, err, ErrInvalidTag, "join keys should require a join table")
+	})
+}
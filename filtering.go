@@ -1,9 +1,23 @@
 package query
 
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
 type Filtering struct {
-	Field  string
-	Filter string
-	Value  any
+	Field     string
+	Filter    string
+	Value     any
+	Requested string
+	Column    string
+}
+
+type Range struct {
+	Lower     any
+	Upper     any
+	Exclusive bool
 }
 
 const (
@@ -14,8 +28,24 @@ const (
 	FilterGreateThanEquals = "gte"
 	FilterLike             = "like"
 	FilterNotEquals        = "neq"
+	FilterIsNull           = "isnull"
+	FilterNotNull          = "notnull"
+	FilterIn               = "in"
+	FilterNotIn            = "nin"
+	FilterBetween          = "between"
+	FilterBetweenExclusive = "between_exclusive"
+	FilterILike            = "ilike"
+	FilterNotLike          = "notlike"
+	FilterContains         = "contains"
+	FilterIContains        = "icontains"
+	FilterStartsWith       = "startswith"
+	FilterEndsWith         = "endswith"
 )
 
+var LikeEscape = `\`
+
+var NullValue = "null"
+
 var (
 	allowedFilterValues = []string{
 		FilterEquals,
@@ -25,5 +55,118 @@ var (
 		FilterGreateThanEquals,
 		FilterLike,
 		FilterNotEquals,
+		FilterIsNull,
+		FilterNotNull,
+		FilterIn,
+		FilterNotIn,
+		FilterBetween,
+		FilterBetweenExclusive,
+		FilterILike,
+		FilterNotLike,
+		FilterContains,
+		FilterIContains,
+		FilterStartsWith,
+		FilterEndsWith,
+	}
+
+	equalityFilterValues = []string{
+		FilterEquals,
+		FilterNotEquals,
+		FilterIn,
+		FilterNotIn,
+	}
+
+	orderedFilterValues = []string{
+		FilterEquals,
+		FilterNotEquals,
+		FilterLessThan,
+		FilterGreaterThan,
+		FilterLessThanEquals,
+		FilterGreateThanEquals,
+		FilterIn,
+		FilterNotIn,
+		FilterBetween,
+		FilterBetweenExclusive,
+	}
+
+	stringFilterValues = []string{
+		FilterLike,
+		FilterILike,
+		FilterNotLike,
+		FilterContains,
+		FilterIContains,
+		FilterStartsWith,
+		FilterEndsWith,
+	}
+
+	listFilterValues = []string{
+		FilterIn,
+		FilterNotIn,
+		FilterBetween,
+		FilterBetweenExclusive,
+	}
+
+	valuelessFilterValues = []string{
+		FilterIsNull,
+		FilterNotNull,
 	}
 )
+
+func isDroppableFilterError(err error) bool {
+	return errors.Is(err, ErrUnknownOperator) || errors.Is(err, ErrUnsupportedOperator)
+}
+
+func (f Filtering) LikePattern() (string, bool) {
+	value, ok := f.Value.(string)
+
+	if !ok {
+		return "", false
+	}
+
+	switch f.Filter {
+	case FilterLike, FilterILike, FilterNotLike:
+		return value, true
+	case FilterContains, FilterIContains:
+		return "%" + EscapeLike(value) + "%", true
+	case FilterStartsWith:
+		return EscapeLike(value) + "%", true
+	case FilterEndsWith:
+		return "%" + EscapeLike(value), true
+	}
+
+	return "", false
+}
+
+func EscapeLike(v string) string {
+	return strings.NewReplacer(
+		LikeEscape, LikeEscape+LikeEscape,
+		"%", LikeEscape+"%",
+		"_", LikeEscape+"_",
+	).Replace(v)
+}
+
+func typedSlice(values []any) any {
+	if len(values) == 0 {
+		return values
+	}
+
+	elemType := reflect.TypeOf(values[0])
+
+	if elemType == nil {
+		return values
+	}
+
+	for _, value := range values {
+		if reflect.TypeOf(value) != elemType {
+			return values
+		}
+	}
+
+	slice := reflect.MakeSlice(reflect.SliceOf(elemType), 0, len(values))
+
+	for _, value := range values {
+		slice = reflect.Append(slice, reflect.ValueOf(value))
+	}
+
+	return slice.Interface()
+}
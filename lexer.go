@@ -0,0 +1,198 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) isWord(word string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, word)
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+type SyntaxError struct {
+	Pos     int
+	Message string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Message)
+}
+
+type lexer struct {
+	input      string
+	pos        int
+	symbols    []string
+	delimiters string
+	quotes     string
+	backslash  bool
+	peeked     *token
+}
+
+func newLexer(input string, symbols []string, delimiters string, quotes string, backslash bool) *lexer {
+	return &lexer{
+		input:      input,
+		symbols:    symbols,
+		delimiters: delimiters,
+		quotes:     quotes,
+		backslash:  backslash,
+	}
+}
+
+func (l *lexer) errorf(pos int, format string, args ...any) error {
+	return SyntaxError{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (l *lexer) peek() (token, error) {
+	if l.peeked != nil {
+		return *l.peeked, nil
+	}
+
+	t, err := l.scan()
+
+	if err != nil {
+		return t, err
+	}
+
+	l.peeked = &t
+
+	return t, nil
+}
+
+func (l *lexer) next() (token, error) {
+	if l.peeked != nil {
+		t := *l.peeked
+		l.peeked = nil
+
+		return t, nil
+	}
+
+	return l.scan()
+}
+
+func (l *lexer) expect(kind tokenKind, text string) (token, error) {
+	t, err := l.next()
+
+	if err != nil {
+		return t, err
+	}
+
+	if !t.is(kind, text) {
+		return t, l.errorf(t.pos, "expected %q, got %s", text, t)
+	}
+
+	return t, nil
+}
+
+func (l *lexer) adjacent() bool {
+	return l.peeked == nil && l.pos < len(l.input) && !l.isSpace(l.pos)
+}
+
+func (l *lexer) scan() (token, error) {
+	for l.pos < len(l.input) && l.isSpace(l.pos) {
+		_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		l.pos += size
+	}
+
+	start := l.pos
+
+	if start >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	if strings.IndexByte(l.quotes, l.input[start]) >= 0 {
+		return l.scanString()
+	}
+
+	for _, symbol := range l.symbols {
+		if strings.HasPrefix(l.input[start:], symbol) {
+			l.pos += len(symbol)
+
+			return token{kind: tokenSymbol, text: symbol, pos: start}, nil
+		}
+	}
+
+	for l.pos < len(l.input) && !l.isSpace(l.pos) && !l.isDelimiter(l.pos) {
+		l.pos++
+	}
+
+	if l.pos == start {
+		return token{}, l.errorf(start, "unexpected character %q", l.input[start])
+	}
+
+	return token{kind: tokenWord, text: l.input[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) scanString() (token, error) {
+	start := l.pos
+	quote := l.input[start]
+	var b strings.Builder
+
+	l.pos++
+
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+
+		switch {
+		case l.backslash && c == '\\' && l.pos+1 < len(l.input):
+			b.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		case c == quote && !l.backslash && l.pos+1 < len(l.input) && l.input[l.pos+1] == quote:
+			b.WriteByte(quote)
+			l.pos += 2
+		case c == quote:
+			l.pos++
+
+			return token{kind: tokenString, text: b.String(), pos: start}, nil
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+func (l *lexer) isSpace(pos int) bool {
+	r, _ := utf8.DecodeRuneInString(l.input[pos:])
+
+	return unicode.IsSpace(r)
+}
+
+func (l *lexer) isDelimiter(pos int) bool {
+	return strings.IndexByte(l.delimiters, l.input[pos]) >= 0 || strings.IndexByte(l.quotes, l.input[pos]) >= 0
+}
@@ -0,0 +1,150 @@
+package query
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	ParamODataFilter  = "$filter"
+	ParamODataOrderBy = "$orderby"
+	ParamODataTop     = "$top"
+	ParamODataSkip    = "$skip"
+	ParamODataSelect  = "$select"
+	ParamODataCount   = "$count"
+
+	SeparatorODataPath = "/"
+
+	odataFilterValues = map[string]string{
+		"eq": FilterEquals,
+		"ne": FilterNotEquals,
+		"gt": FilterGreaterThan,
+		"ge": FilterGreateThanEquals,
+		"lt": FilterLessThan,
+		"le": FilterLessThanEquals,
+		"in": FilterIn,
+	}
+
+	odataFunctions = map[string]string{
+		"contains":   FilterContains,
+		"startswith": FilterStartsWith,
+		"endswith":   FilterEndsWith,
+	}
+)
+
+func (p *Parser) parseOData(v url.Values) (Query, error) {
+	parsingError := ParsingError{}
+
+	limit, err := p.parseLimit(ParamODataTop, v.Get(ParamODataTop))
+	parsingError.add(err)
+
+	offset, err := p.parseOffset(ParamODataSkip, v.Get(ParamODataSkip))
+	parsingError.add(err)
+
+	selections, err := p.parseODataSelect(v.Get(ParamODataSelect))
+	parsingError.add(err)
+
+	sortings, err := p.parseODataOrderBy(v.Get(ParamODataOrderBy))
+	parsingError.add(err)
+
+	where, err := p.parseWhere(v.Get(ParamODataFilter))
+	parsingError.add(err)
+
+	count, err := p.parseODataCount(v.Get(ParamODataCount))
+	parsingError.add(err)
+
+	return Query{
+		Limit:      limit,
+		Offset:     offset,
+		Select:     selectionFields(selections),
+		Selections: selections,
+		Sortings:   sortings,
+		Filterings: []Filtering{},
+		Where:      where,
+		Count:      count,
+	}, parsingError.err()
+}
+
+func (p *Parser) parseODataFilter(raw string) (Expr, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	w := whereParser{
+		parser:        p,
+		lexer:         newLexer(raw, whereSymbols, whereDelimiters, "'", false),
+		param:         ParamODataFilter,
+		filters:       odataFilterValues,
+		functions:     odataFunctions,
+		pathSeparator: SeparatorODataPath,
+	}
+
+	expr, err := w.parse()
+
+	return expr, wrapSyntaxError(ParamODataFilter, raw, err)
+}
+
+func (p *Parser) parseODataOrderBy(raw string) ([]Sorting, error) {
+	rawParts := p.splitClean(raw, SeparatorField, -1)
+	sortings := make([]Sorting, 0, len(rawParts))
+	parsingError := ParsingError{}
+
+	for _, rawPart := range rawParts {
+		parts := strings.Fields(rawPart)
+		order := OrderAsc
+
+		if len(parts) > 2 {
+			parsingError.add(p.strictError(newFieldError(CodeUnknownOrder, ParamODataOrderBy, parts[0], "", rawPart, ErrUnknownOrder)))
+			continue
+		}
+
+		if len(parts) > 1 {
+			order = strings.ToLower(parts[1])
+		}
+
+		requested := p.odataPath(parts[0])
+		sorting, err := p.newSorting(ParamODataOrderBy, requested, order, rawPart)
+
+		if err != nil {
+			parsingError.add(p.strictError(err))
+			continue
+		}
+
+		sortings = append(sortings, sorting)
+	}
+
+	return sortings, parsingError.err()
+}
+
+func (p *Parser) parseODataSelect(raw string) ([]Selection, error) {
+	names := p.splitClean(raw, SeparatorField, -1)
+
+	for i, name := range names {
+		names[i] = p.odataPath(name)
+	}
+
+	return p.parseSelections(ParamODataSelect, names)
+}
+
+func (p *Parser) parseODataCount(raw string) (CountMode, error) {
+	if len(raw) == 0 {
+		return CountNone, nil
+	}
+
+	count, err := strconv.ParseBool(raw)
+
+	if err != nil {
+		return CountNone, p.strictError(newFieldError(CodeInvalidValue, ParamODataCount, "", "", raw, ErrInvalidCount))
+	}
+
+	if !count {
+		return CountNone, nil
+	}
+
+	return p.allowCount(ParamODataCount, raw, CountExact)
+}
+
+func (p *Parser) odataPath(name string) string {
+	return strings.ReplaceAll(name, SeparatorODataPath, SeparatorSelector)
+}
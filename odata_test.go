@@ -0,0 +1,115 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOData(t *testing.T) {
+	postParser := query.MustParser(query.NewParser[examplePost]()).WithOData(true)
+	odataTicketParser := query.MustParser(query.NewParser[exampleTicket]()).WithOData(true)
+
+	t.Run("query", func(t *testing.T) {
+		values := url.Values{
+			query.ParamODataFilter:  {"author/first_name eq 'John' and id gt 3"},
+			query.ParamODataOrderBy: {"author/last_name desc, id"},
+			query.ParamODataTop:     {"20"},
+			query.ParamODataSkip:    {"40"},
+			query.ParamODataSelect:  {"title,author/first_name"},
+			query.ParamODataCount:   {"true"},
+		}
+		expected := query.Query{
+			Limit:  20,
+			Offset: 40,
+			Select: []string{"title", "author.first_name"},
+			Selections: []query.Selection{
+				{Field: "title", Requested: "title", Column: "title"},
+				{Field: "author.first_name", Requested: "author.first_name", Column: "author.first_name"},
+			},
+			Sortings: []query.Sorting{
+				{Field: "author.last_name", Order: query.OrderDesc, Requested: "author.last_name", Column: "author.last_name"},
+				{Field: "id", Order: query.OrderAsc, Requested: "id", Column: "id"},
+			},
+			Filterings: []query.Filtering{},
+			Where: query.AndExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "author.first_name", Filter: query.FilterEquals, Value: "John", Requested: "author.first_name", Column: "author.first_name"},
+				query.Filtering{Field: "id", Filter: query.FilterGreaterThan, Value: 3, Requested: "id", Column: "id"},
+			}},
+			Count: query.CountExact,
+		}
+		q, err := postParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q, "query should be equal")
+	})
+
+	t.Run("operators", func(t *testing.T) {
+		where, err := odataTicketParser.ParseFilter("status ne 'closed' and (priority ge 2 or priority le -1) and id in (1, 2) and assignee ne null")
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "status", Filter: query.FilterNotEquals, Value: "closed", Requested: "status", Column: "status"},
+			query.OrExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "priority", Filter: query.FilterGreateThanEquals, Value: 2, Requested: "priority", Column: "priority"},
+				query.Filtering{Field: "priority", Filter: query.FilterLessThanEquals, Value: -1, Requested: "priority", Column: "priority"},
+			}},
+			query.Filtering{Field: "id", Filter: query.FilterIn, Value: []int{1, 2}, Requested: "id", Column: "id"},
+			query.Filtering{Field: "assignee", Filter: query.FilterNotNull, Requested: "assignee", Column: "assignee"},
+		}}, where, "expression should be equal")
+	})
+
+	t.Run("functions", func(t *testing.T) {
+		where, err := postParser.ParseFilter("contains(title, 'go') or not startswith(author/last_name, 'O''B')")
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.OrExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "title", Filter: query.FilterContains, Value: "go", Requested: "title", Column: "title"},
+			query.NotExpr{Expr: query.Filtering{Field: "author.last_name", Filter: query.FilterStartsWith, Value: "O'B", Requested: "author.last_name", Column: "author.last_name"}},
+		}}, where, "expression should be equal")
+	})
+
+	t.Run("unknown-operator", func(t *testing.T) {
+		_, err := postParser.ParseFilter("id gte 3")
+
+		var fieldError query.FieldError
+
+		assert.True(t, errors.As(err, &fieldError), "should return a field error")
+		assert.Equal(t, query.ParamODataFilter, fieldError.Param, "param should be equal")
+		assert.Equal(t, query.CodeUnknownOperator, fieldError.Code, "code should be equal")
+	})
+
+	t.Run("syntax-error", func(t *testing.T) {
+		_, err := postParser.Parse(url.Values{query.ParamODataFilter: {"contains(title 'go')"}})
+
+		var syntaxError query.SyntaxError
+
+		assert.True(t, errors.As(err, &syntaxError), "should return a syntax error")
+		assert.Equal(t, 15, syntaxError.Pos, "position should be equal")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		strictParser := query.MustParser(query.NewParser[examplePost]()).WithOData(true).WithStrict(true)
+		values := url.Values{
+			query.ParamODataOrderBy: {"title sideways"},
+			query.ParamODataTop:     {"-1"},
+			query.ParamODataCount:   {"maybe"},
+		}
+		_, err := strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		assert.True(t, errors.As(err, &parsingError), "should return a parsing error")
+		assert.Len(t, parsingError.Errors, 3, "should report every invalid parameter")
+
+		q, err := postParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, query.DefaultBaseLimit, q.Limit, "limit should fall back to the base limit")
+		assert.Empty(t, q.Sortings, "unknown orders should be dropped")
+		assert.Equal(t, query.CountNone, q.Count, "count should be disabled")
+	})
+}
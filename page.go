@@ -0,0 +1,71 @@
+package query
+
+import (
+	"errors"
+	"math"
+	"net/url"
+)
+
+const DefaultBasePage = 1
+
+var (
+	ParamPage       = "page"
+	ParamPerPage    = "per_page"
+	ParamPageNumber = "page[number]"
+
+	ErrInvalidPage = errors.New("invalid page")
+)
+
+type PaginationMode string
+
+const (
+	PaginationOffset PaginationMode = "offset"
+	PaginationPage   PaginationMode = "page"
+	PaginationBoth   PaginationMode = "both"
+)
+
+func (p *Parser) parsePagination(v url.Values) (int, int, int, error) {
+	parsingError := ParsingError{}
+	pageParam := p.pageParam(v, ParamPage, ParamPageNumber)
+	perPageParam := p.pageParam(v, ParamPerPage, ParamPageSize)
+	usePage := p.paginationMode == PaginationPage || (p.paginationMode == PaginationBoth && v.Has(pageParam))
+	usePerPage := p.paginationMode == PaginationPage || (p.paginationMode == PaginationBoth && v.Has(perPageParam))
+
+	limitParam := p.pageParam(v, ParamLimit, ParamPageSize, ParamPageLimit)
+
+	if usePerPage {
+		limitParam = perPageParam
+	}
+
+	limit, err := p.parseLimit(limitParam, v.Get(limitParam))
+	parsingError.add(err)
+
+	if !usePage {
+		offsetParam := p.pageParam(v, ParamOffset, ParamPageOffset)
+		offset, err := p.parseOffset(offsetParam, v.Get(offsetParam))
+		parsingError.add(err)
+
+		return limit, offset, 0, parsingError.err()
+	}
+
+	page, err := p.parsePage(pageParam, v.Get(pageParam), limit)
+	parsingError.add(err)
+
+	return limit, (page - 1) * limit, page, parsingError.err()
+}
+
+func (p *Parser) parsePage(param string, raw string, limit int) (int, error) {
+	page, err := p.parseInt(raw, DefaultBasePage)
+
+	if err != nil || page < 1 || (limit <= 0 && page > 1) || (limit > 0 && page-1 > math.MaxInt/limit) {
+		return DefaultBasePage, p.strictError(newFieldError(CodeInvalidValue, param, "", "", raw, ErrInvalidPage))
+	}
+
+	return page, nil
+}
+
+func (p *Parser) WithPaginationMode(mode PaginationMode) *Parser {
+	p.paginationMode = mode
+
+	return p
+}
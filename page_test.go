@@ -0,0 +1,83 @@
+package query_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination(t *testing.T) {
+	pageParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationPage)
+	bothParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationBoth)
+
+	tests := []struct {
+		name   string
+		parser *query.Parser
+		query  string
+		limit  int
+		offset int
+		page   int
+	}{
+		{name: "page", parser: pageParser, query: "page=3&per_page=20", limit: 20, offset: 40, page: 3},
+		{name: "page-defaults", parser: pageParser, query: "", limit: query.DefaultBaseLimit, offset: 0, page: 1},
+		{name: "page-ignores-offset", parser: pageParser, query: "offset=5&limit=5&page=2", limit: query.DefaultBaseLimit, offset: 10, page: 2},
+		{name: "page-max-limit", parser: pageParser, query: "page=2&per_page=1000", limit: query.DefaultMaxLimit, offset: 100, page: 2},
+		{name: "page-invalid", parser: pageParser, query: "page=0", limit: query.DefaultBaseLimit, offset: 0, page: 1},
+		{name: "offset-ignores-page", parser: parser, query: "page=3&per_page=20", limit: query.DefaultBaseLimit, offset: 0, page: 0},
+		{name: "both-offset", parser: bothParser, query: "offset=5&limit=5", limit: 5, offset: 5, page: 0},
+		{name: "both-page-precedence", parser: bothParser, query: "offset=5&limit=5&page=3&per_page=20", limit: 20, offset: 40, page: 3},
+		{name: "both-mixed", parser: bothParser, query: "limit=5&page=3", limit: 5, offset: 10, page: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(test.query)
+			q, err := test.parser.Parse(values)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, test.limit, q.Limit, "limit should be equal")
+			assert.Equal(t, test.offset, q.Offset, "offset should be equal")
+			assert.Equal(t, test.page, q.Page, "page should be equal")
+		})
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		strictParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationPage).WithStrict(true)
+		values, _ := url.ParseQuery("page=-1&per_page=0")
+		_, err := strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		assert.True(t, errors.As(err, &parsingError), "should return a parsing error")
+		assert.Len(t, parsingError.Errors, 2, "should report page and per_page")
+		assert.ErrorIs(t, err, query.ErrInvalidPage, "should report an invalid page")
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		unlimitedParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationPage).WithBaseLimit(0)
+		values, _ := url.ParseQuery("page=2")
+		q, err := unlimitedParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, 0, q.Limit, "limit should be equal")
+		assert.Equal(t, 0, q.Offset, "offset should be equal")
+		assert.Equal(t, 1, q.Page, "page should be equal")
+
+		_, err = unlimitedParser.WithStrict(true).Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidPage, "should reject pages after the first without a limit")
+	})
+
+	t.Run("bracket-page-number", func(t *testing.T) {
+		bracketParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationPage).WithBracketSyntax(true)
+		values, _ := url.ParseQuery("page[number]=2&page[size]=15")
+		q, err := bracketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, 15, q.Offset, "offset should be equal")
+		assert.Equal(t, 2, q.Page, "page should be equal")
+	})
+}
@@ -0,0 +1,161 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const TotalUnknown = -1
+
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Page       int    `json:"page"`
+	Total      *int   `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	HasNext    *bool  `json:"has_next,omitempty"`
+	HasPrev    *bool  `json:"has_prev,omitempty"`
+	First      string `json:"first,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Last       string `json:"last,omitempty"`
+}
+
+func (p *Parser) Paginate(q Query, u *url.URL, total int) Pagination {
+	limit := min(q.Limit, p.maxLimit)
+
+	if limit <= 0 {
+		limit = p.baseLimit
+	}
+
+	if limit <= 0 || q.Cursor != nil {
+		return p.paginateSingle(q, u, max(limit, 0), total)
+	}
+
+	offset := max(q.Offset, 0)
+	hasNext := total < 0 || offset+limit < total
+	hasPrev := offset > 0
+	pagination := Pagination{
+		Limit:   limit,
+		Offset:  offset,
+		Page:    offset/limit + 1,
+		HasNext: &hasNext,
+		HasPrev: &hasPrev,
+	}
+
+	pagination.First = p.paginationURL(q, u, 0, limit)
+
+	if hasPrev {
+		pagination.Prev = p.paginationURL(q, u, max(offset-limit, 0), limit)
+	}
+
+	if hasNext {
+		pagination.Next = p.paginationURL(q, u, offset+limit, limit)
+	}
+
+	if total >= 0 {
+		totalPages := (total + limit - 1) / limit
+
+		pagination.Total = &total
+		pagination.TotalPages = &totalPages
+		pagination.Last = p.paginationURL(q, u, max(totalPages-1, 0)*limit, limit)
+	}
+
+	return pagination
+}
+
+func (p *Parser) paginateSingle(q Query, u *url.URL, limit int, total int) Pagination {
+	pagination := Pagination{
+		Limit:  limit,
+		Offset: max(q.Offset, 0),
+		First:  p.paginationURL(q, u, 0, limit),
+	}
+
+	if q.Cursor == nil {
+		hasNext := false
+		hasPrev := pagination.Offset > 0
+
+		pagination.Page = DefaultBasePage
+		pagination.HasNext = &hasNext
+		pagination.HasPrev = &hasPrev
+
+		if hasPrev {
+			pagination.Prev = pagination.First
+		}
+	}
+
+	if total >= 0 {
+		totalPages := 0
+
+		switch {
+		case limit > 0:
+			totalPages = (total + limit - 1) / limit
+		case total > 0:
+			totalPages = 1
+		}
+
+		pagination.Total = &total
+		pagination.TotalPages = &totalPages
+	}
+
+	return pagination
+}
+
+func (p *Parser) paginationURL(q Query, u *url.URL, offset int, limit int) string {
+	values := u.Query()
+	values.Del(ParamCursor)
+
+	switch {
+	case limit <= 0:
+		for _, param := range paginationParams() {
+			values.Del(param)
+		}
+	case p.odata:
+		values.Set(ParamODataTop, strconv.Itoa(limit))
+		values.Set(ParamODataSkip, strconv.Itoa(offset))
+	case q.Page > 0:
+		values.Set(p.pageParam(values, ParamPerPage, ParamPageSize), strconv.Itoa(limit))
+		values.Set(p.pageParam(values, ParamPage, ParamPageNumber), strconv.Itoa(offset/limit+1))
+	default:
+		values.Set(p.pageParam(values, ParamLimit, ParamPageSize, ParamPageLimit), strconv.Itoa(limit))
+		values.Set(p.pageParam(values, ParamOffset, ParamPageOffset), strconv.Itoa(offset))
+	}
+
+	link := *u
+	link.RawQuery = values.Encode()
+
+	return link.String()
+}
+
+func paginationParams() []string {
+	return []string{
+		ParamLimit, ParamOffset, ParamPage, ParamPerPage, ParamPageNumber,
+		ParamPageSize, ParamPageLimit, ParamPageOffset, ParamODataTop, ParamODataSkip,
+	}
+}
+
+func (pg Pagination) Link() string {
+	links := make([]string, 0, 4)
+
+	for _, link := range []struct{ rel, url string }{
+		{"first", pg.First},
+		{"prev", pg.Prev},
+		{"next", pg.Next},
+		{"last", pg.Last},
+	} {
+		if len(link.url) > 0 {
+			links = append(links, fmt.Sprintf("<%s>; rel=%q", link.url, link.rel))
+		}
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func (pg Pagination) SetLinkHeader(h http.Header) {
+	if link := pg.Link(); len(link) > 0 {
+		h.Set("Link", link)
+	}
+}
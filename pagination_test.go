@@ -0,0 +1,129 @@
+package query_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		u, _ := url.Parse("https://example.com/posts?author.first_name=John&id=gt:1&limit=10&offset=20&select=title&sort=id:desc")
+		q, _ := parser.Parse(u.Query())
+		pagination := parser.Paginate(q, u, 45)
+
+		assert.Equal(t, 3, pagination.Page, "page should be equal")
+		assert.Equal(t, 45, *pagination.Total, "total should be equal")
+		assert.Equal(t, 5, *pagination.TotalPages, "total pages should be equal")
+		assert.True(t, *pagination.HasNext, "should have a next page")
+		assert.True(t, *pagination.HasPrev, "should have a previous page")
+		assert.Equal(t, "https://example.com/posts?author.first_name=John&id=gt%3A1&limit=10&offset=0&select=title&sort=id%3Adesc", pagination.First, "first should be equal")
+		assert.Equal(t, "https://example.com/posts?author.first_name=John&id=gt%3A1&limit=10&offset=10&select=title&sort=id%3Adesc", pagination.Prev, "prev should be equal")
+		assert.Equal(t, "https://example.com/posts?author.first_name=John&id=gt%3A1&limit=10&offset=30&select=title&sort=id%3Adesc", pagination.Next, "next should be equal")
+		assert.Equal(t, "https://example.com/posts?author.first_name=John&id=gt%3A1&limit=10&offset=40&select=title&sort=id%3Adesc", pagination.Last, "last should be equal")
+
+		header := http.Header{}
+		pagination.SetLinkHeader(header)
+
+		assert.Equal(t, `<`+pagination.First+`>; rel="first", <`+pagination.Prev+`>; rel="prev", <`+pagination.Next+`>; rel="next", <`+pagination.Last+`>; rel="last"`, header.Get("Link"), "link header should be equal")
+	})
+
+	t.Run("last-page", func(t *testing.T) {
+		u, _ := url.Parse("/posts?offset=40")
+		q, _ := parser.Parse(u.Query())
+		pagination := parser.Paginate(q, u, 45)
+
+		assert.False(t, *pagination.HasNext, "should not have a next page")
+		assert.Empty(t, pagination.Next, "next should be empty")
+		assert.Equal(t, "/posts?limit=10&offset=30", pagination.Prev, "prev should be equal")
+	})
+
+	t.Run("unknown-total", func(t *testing.T) {
+		u, _ := url.Parse("/posts")
+		q, _ := parser.Parse(u.Query())
+		pagination := parser.Paginate(q, u, query.TotalUnknown)
+
+		assert.Nil(t, pagination.Total, "total should be unknown")
+		assert.Nil(t, pagination.TotalPages, "total pages should be unknown")
+		assert.False(t, *pagination.HasPrev, "should not have a previous page")
+		assert.Equal(t, "/posts?limit=10&offset=10", pagination.Next, "next should be equal")
+		assert.Empty(t, pagination.Last, "last should be empty")
+		assert.Equal(t, `</posts?limit=10&offset=0>; rel="first", </posts?limit=10&offset=10>; rel="next"`, pagination.Link(), "link should be equal")
+	})
+
+	t.Run("max-limit", func(t *testing.T) {
+		u, _ := url.Parse("/posts?limit=500")
+		pagination := parser.Paginate(query.Query{Limit: 500}, u, 250)
+
+		assert.Equal(t, query.DefaultMaxLimit, pagination.Limit, "limit should be clamped")
+		assert.Equal(t, "/posts?limit=100&offset=200", pagination.Last, "last should be equal")
+	})
+
+	t.Run("page", func(t *testing.T) {
+		pageParser := query.MustParser(query.NewParser[examplePost]()).WithPaginationMode(query.PaginationPage)
+		u, _ := url.Parse("/posts?page=2&per_page=20&sort=id")
+		q, _ := pageParser.Parse(u.Query())
+		pagination := pageParser.Paginate(q, u, 0)
+
+		assert.Equal(t, 2, pagination.Page, "page should be equal")
+		assert.Equal(t, 0, *pagination.TotalPages, "total pages should be equal")
+		assert.False(t, *pagination.HasNext, "should not have a next page")
+		assert.Equal(t, "/posts?page=1&per_page=20&sort=id", pagination.Prev, "prev should be equal")
+		assert.Equal(t, "/posts?page=1&per_page=20&sort=id", pagination.Last, "last should be equal")
+	})
+
+	t.Run("odata", func(t *testing.T) {
+		odataParser := query.MustParser(query.NewParser[examplePost]()).WithOData(true)
+		u, _ := url.Parse("/posts?$filter=id gt 1&$top=5")
+		q, _ := odataParser.Parse(u.Query())
+		pagination := odataParser.Paginate(q, u, 12)
+
+		assert.Equal(t, "/posts?%24filter=id+gt+1&%24skip=5&%24top=5", pagination.Next, "next should be equal")
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		unlimitedParser := query.MustParser(query.NewParser[examplePost]()).WithBaseLimit(0)
+		u, _ := url.Parse("/posts?id=gt:1&offset=5")
+		q, _ := unlimitedParser.Parse(u.Query())
+		pagination := unlimitedParser.Paginate(q, u, 45)
+
+		assert.Equal(t, 0, pagination.Limit, "limit should be equal")
+		assert.Equal(t, 1, pagination.Page, "page should be equal")
+		assert.Equal(t, 1, *pagination.TotalPages, "total pages should be equal")
+		assert.False(t, *pagination.HasNext, "should not have a next page")
+		assert.True(t, *pagination.HasPrev, "should have a previous page")
+		assert.Equal(t, `</posts?id=gt%3A1>; rel="first", </posts?id=gt%3A1>; rel="prev"`, pagination.Link(), "link should be equal")
+
+		pagination = unlimitedParser.Paginate(q, u, 0)
+
+		assert.Equal(t, 0, *pagination.TotalPages, "total pages should be equal")
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		cursorParser := query.MustParser(query.NewParser[examplePost]()).WithCursor([]byte("secret"))
+		u, _ := url.Parse("/posts?sort=id&limit=10")
+		q, _ := cursorParser.Parse(u.Query())
+		cursor, err := cursorParser.NextCursor(q, examplePost{Id: 10})
+
+		assert.NoError(t, err, "should not return an error")
+
+		values := u.Query()
+		values.Set(query.ParamCursor, cursor)
+		u.RawQuery = values.Encode()
+		q, _ = cursorParser.Parse(u.Query())
+		pagination := cursorParser.Paginate(q, u, 45)
+
+		assert.NotNil(t, q.Cursor, "cursor should be set")
+		assert.Equal(t, 0, pagination.Page, "page should be unknown")
+		assert.Equal(t, 5, *pagination.TotalPages, "total pages should be equal")
+		assert.Nil(t, pagination.HasNext, "should leave next to the cursor")
+		assert.Nil(t, pagination.HasPrev, "should leave prev to the cursor")
+		assert.Empty(t, pagination.Next, "next should be empty")
+		assert.Empty(t, pagination.Prev, "prev should be empty")
+		assert.Empty(t, pagination.Last, "last should be empty")
+		assert.Equal(t, "/posts?limit=10&offset=0&sort=id", pagination.First, "first should be equal")
+	})
+}
@@ -5,6 +5,11 @@ import (
 	"time"
 )
 
+var DefaultTimeLayouts = []string{
+	time.RFC3339,
+	time.DateOnly,
+}
+
 func ParseString(v string) (any, error) {
 	return v, nil
 }
@@ -64,8 +69,22 @@ func ParseFloat(bitSize int) ParseFunc {
 	}
 }
 
-func ParseTime(layout string) ParseFunc {
+func ParseTime(layouts ...string) ParseFunc {
+	if len(layouts) == 0 {
+		layouts = DefaultTimeLayouts
+	}
+
 	return func(v string) (any, error) {
-		return time.Parse(layout, v)
+		var err error
+
+		for _, layout := range layouts {
+			var t time.Time
+
+			if t, err = time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+
+		return time.Time{}, err
 	}
 }
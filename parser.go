@@ -1,17 +1,22 @@
 package query
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"maps"
 	"net/url"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
 )
 
 const (
-	DefaultMaxLimit   = 100
-	DefaultBaseLimit  = 10
-	DefaultBaseOffset = 0
+	DefaultMaxLimit      = 100
+	DefaultBaseLimit     = 10
+	DefaultBaseOffset    = 0
+	DefaultMaxListLength = 100
 )
 
 var (
@@ -19,17 +24,28 @@ var (
 	ParamOffset = "offset"
 	ParamSelect = "select"
 	ParamSort   = "sort"
+	ParamFilter = "filter"
 
 	SeparatorSelector = "."
 	SeparatorField    = ","
 	SeparatorFilter   = ":"
+	SeparatorList     = "|"
 )
 
 type Parser struct {
-	fields     []field
-	maxLimit   int
-	baseLimit  int
-	baseOffset int
+	typ            reflect.Type
+	fields         []field
+	maxLimit       int
+	baseLimit      int
+	baseOffset     int
+	maxListLength  int
+	strict         bool
+	filterSyntax   FilterSyntax
+	odata          bool
+	brackets       bool
+	cursorSecret   []byte
+	paginationMode PaginationMode
+	countDisabled  bool
 }
 
 func MustParser(p *Parser, err error) *Parser {
@@ -44,78 +60,353 @@ func NewParser[T any]() (*Parser, error) {
 	fields, err := getFieldsFromStruct[T]()
 
 	return &Parser{
-		fields:     fields,
-		maxLimit:   DefaultMaxLimit,
-		baseLimit:  DefaultBaseLimit,
-		baseOffset: DefaultBaseOffset,
+		typ:            reflect.TypeFor[T](),
+		fields:         fields,
+		maxLimit:       DefaultMaxLimit,
+		baseLimit:      DefaultBaseLimit,
+		baseOffset:     DefaultBaseOffset,
+		maxListLength:  DefaultMaxListLength,
+		filterSyntax:   FilterSyntaxExpression,
+		paginationMode: PaginationOffset,
 	}, err
 }
 
 func (p *Parser) Parse(v url.Values, excludes ...string) (Query, error) {
+	if p.odata {
+		return p.parseOData(v)
+	}
+
+	parsingError := ParsingError{}
+
+	limit, offset, page, err := p.parsePagination(v)
+	parsingError.add(err)
+
+	selections, err := p.parseSelect(v.Get(ParamSelect))
+	parsingError.add(err)
+
+	sortings, err := p.parseSort(v.Get(ParamSort))
+	parsingError.add(err)
+
 	filterings, err := p.parseFilter(v)
+	parsingError.add(err)
+
+	parsingError.add(p.parseUnknown(v, excludes))
+
+	where, err := p.parseWhere(v.Get(ParamFilter))
+	parsingError.add(err)
+
+	count, err := p.parseCount(v.Get(ParamCount))
+	parsingError.add(err)
+
+	cursor, err := p.parseCursor(v.Get(ParamCursor), sortings, filterings, where)
+	parsingError.add(err)
+
+	if cursor != nil {
+		offset, page = 0, 0
+	}
 
 	return Query{
-		Limit:      p.parseLimit(v.Get(ParamLimit)),
-		Offset:     p.parseOffset(v.Get(ParamOffset)),
-		Select:     p.parseSelect(v.Get(ParamSelect)),
-		Sortings:   p.parseSort(v.Get(ParamSort)),
+		Limit:      limit,
+		Offset:     offset,
+		Page:       page,
+		Select:     selectionFields(selections),
+		Selections: selections,
+		Sortings:   sortings,
 		Filterings: filterings,
-	}, err
+		Where:      where,
+		Count:      count,
+		Cursor:     cursor,
+	}, parsingError.err()
+}
+
+func (p *Parser) parseUnknown(values url.Values, excludes []string) error {
+	if !p.strict {
+		return nil
+	}
+
+	parsingError := ParsingError{}
+	reserved := []string{
+		ParamLimit, ParamOffset, ParamSelect, ParamSort, ParamFilter, ParamCount, ParamCursor,
+		ParamPage, ParamPerPage, ParamPageNumber, ParamPageSize, ParamPageLimit, ParamPageOffset,
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if slices.Contains(reserved, key) || slices.Contains(excludes, key) {
+			continue
+		}
+
+		if p.brackets && strings.HasPrefix(key, ParamFilter+"[") {
+			continue
+		}
+
+		if _, ok := p.lookupField(key); ok {
+			continue
+		}
+
+		parsingError.add(newFieldError(CodeUnknownField, key, key, "", values.Get(key), ErrUnknownField))
+	}
+
+	return parsingError.err()
 }
 
 func (p *Parser) parseFilter(values url.Values) ([]Filtering, error) {
 	filterings := make([]Filtering, 0, len(p.fields))
 	parsingError := ParsingError{}
+	bracketFilters := map[string][]bracketFilter{}
+
+	if p.brackets {
+		var err error
+
+		bracketFilters, err = p.parseBracketFilter(values)
+		parsingError.add(err)
+	}
 
 	for _, field := range p.fields {
-		raw := values.Get(field.name)
+		for _, name := range field.names() {
+			filterings = p.appendFilterings(filterings, &parsingError, field, name, values[name])
+			filterings = p.appendBracketFilterings(filterings, &parsingError, field, name, bracketFilters[name])
+		}
+	}
+
+	return filterings, parsingError.err()
+}
+
+func (p *Parser) appendFilterings(filterings []Filtering, parsingError *ParsingError, f field, requested string, raws []string) []Filtering {
+	if len(raws) == 0 {
+		return filterings
+	}
+
+	if !f.filterable {
+		if p.strict {
+			parsingError.add(newFieldError(CodeNotFilterable, requested, f.name, "", raws[0], ErrNotFilterable))
+		}
+
+		return filterings
+	}
+
+	for _, raw := range raws {
+		for _, condition := range p.splitConditions(raw) {
+			filter, value, ok := p.splitCondition(f, condition)
 
-		if len(raw) == 0 {
+			if !ok {
+				if p.strict {
+					parsingError.add(newFieldError(CodeInvalidValue, requested, f.name, "", condition, ErrEmptyCondition))
+				}
+
+				continue
+			}
+
+			filtering, err := p.newFiltering(requested, f, requested, filter, p.splitFilterArgs(filter, value))
+
+			if err != nil {
+				if p.strict || !isDroppableFilterError(err) {
+					parsingError.add(err)
+				}
+
+				continue
+			}
+
+			filterings = append(filterings, filtering)
+		}
+	}
+
+	return filterings
+}
+
+func (p *Parser) splitConditions(raw string) []string {
+	conditions := make([]string, 0, 1)
+
+	for _, rawPart := range strings.Split(raw, SeparatorField) {
+		part := strings.TrimSpace(rawPart)
+
+		switch {
+		case len(part) == 0:
 			continue
+		case len(conditions) > 0 && !p.isConditionStart(part):
+			conditions[len(conditions)-1] += SeparatorField + rawPart
+		default:
+			conditions = append(conditions, part)
 		}
+	}
+
+	return conditions
+}
 
-		parts := p.splitClean(raw, SeparatorFilter, 2)
-		filtering, err := p.newFiltering(field.name, field.parseFunc, parts)
+func (p *Parser) isConditionStart(part string) bool {
+	if filter, _, ok := strings.Cut(part, SeparatorFilter); ok {
+		return p.isAllowedFilterValue(strings.TrimSpace(filter))
+	}
 
-		if err != nil {
-			parsingError.Errors = append(parsingError.Errors, err)
+	return slices.Contains(valuelessFilterValues, part)
+}
+
+func (p *Parser) splitCondition(f field, condition string) (string, string, bool) {
+	filter, value, ok := p.splitFilter(f, condition)
+
+	if ok && f.nullable && value == NullValue {
+		switch filter {
+		case FilterEquals:
+			return FilterIsNull, "", true
+		case FilterNotEquals:
+			return FilterNotNull, "", true
+		}
+	}
+
+	return filter, value, ok
+}
+
+func (p *Parser) splitFilter(f field, condition string) (string, string, bool) {
+	parts := p.splitClean(condition, SeparatorFilter, 2)
+
+	switch len(parts) {
+	case 0:
+		return "", "", false
+	case 1:
+		if f.nullable && slices.Contains(valuelessFilterValues, parts[0]) {
+			return parts[0], "", true
 		}
 
-		filterings = append(filterings, filtering)
+		return FilterEquals, parts[0], true
+	}
+
+	if p.isAllowedFilterValue(parts[0]) || f.typ.Kind() == reflect.String {
+		return parts[0], parts[1], true
 	}
 
-	if len(parsingError.Errors) > 0 {
-		return filterings, parsingError
+	if _, err := f.parseFunc(condition); err == nil {
+		return FilterEquals, condition, true
 	}
 
-	return filterings, nil
+	return parts[0], parts[1], true
 }
 
-func (p *Parser) newFiltering(field string, parse ParseFunc, parts []string) (Filtering, error) {
-	var err error
+func (p *Parser) splitFilterArgs(filter string, value string) []string {
+	switch {
+	case slices.Contains(listFilterValues, filter):
+		return p.splitClean(value, SeparatorList, -1)
+	case len(value) == 0:
+		return nil
+	}
+
+	return []string{value}
+}
+
+func (p *Parser) newFiltering(param string, f field, requested string, filter string, args []string) (Filtering, error) {
+	value := strings.Join(args, SeparatorList)
+
+	if !f.filterable {
+		return Filtering{}, newFieldError(CodeNotFilterable, param, f.name, filter, value, ErrNotFilterable)
+	}
 
-	filtering := Filtering{
-		Field: field,
+	if !p.isAllowedFilterValue(filter) {
+		return Filtering{}, newFieldError(CodeUnknownOperator, param, f.name, filter, value, ErrUnknownOperator)
 	}
 
-	if len(parts) == 1 {
-		filtering.Filter = FilterEquals
-		filtering.Value, err = parse(parts[0])
-	} else {
-		if !p.isAllowedFilterValue(parts[0]) {
-			return Filtering{}, nil
+	if !f.allowsFilter(filter) {
+		return Filtering{}, newFieldError(CodeUnsupportedOperator, param, f.name, filter, value, ErrUnsupportedOperator)
+	}
+
+	parsed, err := p.parseFilterValue(f, filter, args)
+
+	if err != nil {
+		code := CodeInvalidValue
+
+		if errors.Is(err, ErrListTooLong) {
+			code = CodeListTooLong
 		}
 
-		filtering.Filter = parts[0]
-		filtering.Value, err = parse(parts[1])
+		return Filtering{}, newFieldError(code, param, f.name, filter, value, err)
 	}
 
-	return filtering, err
+	return Filtering{
+		Field:     f.name,
+		Filter:    filter,
+		Value:     parsed,
+		Requested: requested,
+		Column:    f.column,
+	}, nil
 }
 
-func (p *Parser) parseSort(raw string) []Sorting {
+func (p *Parser) parseFilterValue(f field, filter string, args []string) (any, error) {
+	switch filter {
+	case FilterIsNull, FilterNotNull:
+		if len(args) > 0 {
+			return nil, ErrUnexpectedValue
+		}
+
+		return nil, nil
+	case FilterIn, FilterNotIn:
+		return p.parseListValue(f, args)
+	case FilterBetween, FilterBetweenExclusive:
+		return p.parseRangeValue(f, args, filter == FilterBetweenExclusive)
+	}
+
+	switch len(args) {
+	case 0:
+		return nil, ErrMissingValue
+	case 1:
+		return f.parseFunc(args[0])
+	}
+
+	return nil, ErrUnexpectedList
+}
+
+func (p *Parser) parseListValue(f field, items []string) (any, error) {
+	if len(items) == 0 {
+		return nil, ErrEmptyList
+	}
+
+	if len(items) > p.maxListLength {
+		return nil, fmt.Errorf("%w: maximum is %d", ErrListTooLong, p.maxListLength)
+	}
+
+	values := make([]any, 0, len(items))
+
+	for _, item := range items {
+		parsed, err := f.parseFunc(item)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, parsed)
+	}
+
+	return typedSlice(values), nil
+}
+
+func (p *Parser) parseRangeValue(f field, bounds []string, exclusive bool) (any, error) {
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("%w: expected two bounds", ErrInvalidRange)
+	}
+
+	lower, err := f.parseFunc(bounds[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	upper, err := f.parseFunc(bounds[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c, ok := compareValues(lower, upper); ok && c > 0 {
+		return nil, fmt.Errorf("%w: lower bound is greater than upper bound", ErrInvalidRange)
+	}
+
+	return Range{
+		Lower:     lower,
+		Upper:     upper,
+		Exclusive: exclusive,
+	}, nil
+}
+
+func (p *Parser) parseSort(raw string) ([]Sorting, error) {
 	rawParts := p.splitClean(raw, SeparatorField, -1)
 	sortings := make([]Sorting, 0, len(rawParts))
+	parsingError := ParsingError{}
 
 	for _, rawPart := range rawParts {
 		parts := p.splitClean(rawPart, SeparatorFilter, 2)
@@ -124,75 +415,133 @@ func (p *Parser) parseSort(raw string) []Sorting {
 			continue
 		}
 
-		if !p.isAllowedField(parts[0]) {
-			continue
+		requested, order := parts[0], OrderAsc
+
+		if descending, ok := strings.CutPrefix(requested, "-"); ok && p.brackets {
+			requested, order = descending, OrderDesc
 		}
 
-		if len(parts) < 2 {
-			sortings = append(sortings, Sorting{
-				Field: parts[0],
-				Order: OrderAsc,
-			})
-			continue
+		if len(parts) > 1 {
+			order = parts[1]
 		}
 
-		if !p.isAllowedOrderValue(parts[1]) {
+		sorting, err := p.newSorting(ParamSort, requested, order, rawPart)
+
+		if err != nil {
+			parsingError.add(p.strictError(err))
 			continue
 		}
 
-		sortings = append(sortings, Sorting{
-			Field: parts[0],
-			Order: parts[1],
-		})
+		sortings = append(sortings, sorting)
 	}
 
-	return sortings
+	return sortings, parsingError.err()
 }
 
-func (p *Parser) parseSelect(raw string) []string {
-	fields := p.splitClean(raw, SeparatorField, -1)
+func (p *Parser) newSorting(param string, requested string, order string, raw string) (Sorting, error) {
+	field, ok := p.lookupField(requested)
 
-	return slices.DeleteFunc(fields, func(field string) bool {
-		return !p.isAllowedField(field)
-	})
+	if !ok {
+		return Sorting{}, newFieldError(CodeUnknownField, param, requested, "", raw, ErrUnknownField)
+	}
+
+	if !field.sortable {
+		return Sorting{}, newFieldError(CodeNotSortable, param, field.name, "", raw, ErrNotSortable)
+	}
+
+	if !p.isAllowedOrderValue(order) {
+		return Sorting{}, newFieldError(CodeUnknownOrder, param, field.name, "", order, ErrUnknownOrder)
+	}
+
+	if baseOrder, ok := nullsOrderValues[order]; ok && !field.nullable {
+		if p.strict {
+			return Sorting{}, newFieldError(CodeUnsupportedOrder, param, field.name, "", order, ErrUnsupportedOrder)
+		}
+
+		order = baseOrder
+	}
+
+	return Sorting{
+		Field:     field.name,
+		Order:     order,
+		Requested: requested,
+		Column:    field.column,
+		Nullable:  field.nullable,
+	}, nil
+}
+
+func (p *Parser) parseSelect(raw string) ([]Selection, error) {
+	return p.parseSelections(ParamSelect, p.splitClean(raw, SeparatorField, -1))
 }
 
-func (p *Parser) parseLimit(raw string) int {
-	limit := p.parseInt(raw, p.baseLimit)
+func (p *Parser) parseSelections(param string, names []string) ([]Selection, error) {
+	selections := make([]Selection, 0, len(names))
+	parsingError := ParsingError{}
+
+	for _, name := range names {
+		field, ok := p.lookupField(name)
+
+		if !ok {
+			parsingError.add(p.strictError(newFieldError(CodeUnknownField, param, name, "", name, ErrUnknownField)))
+			continue
+		}
+
+		if !field.selectable {
+			parsingError.add(p.strictError(newFieldError(CodeNotSelectable, param, field.name, "", name, ErrNotSelectable)))
+			continue
+		}
 
-	if limit <= 0 {
-		return p.baseLimit
+		selections = append(selections, Selection{
+			Field:     field.name,
+			Requested: name,
+			Column:    field.column,
+			Composite: !isLeafType(field.typ),
+		})
+	}
+
+	return selections, parsingError.err()
+}
+
+func (p *Parser) parseLimit(param string, raw string) (int, error) {
+	limit, err := p.parseInt(raw, p.baseLimit)
+
+	if err != nil || limit <= 0 {
+		return p.baseLimit, p.strictError(newFieldError(CodeInvalidValue, param, "", "", raw, ErrInvalidLimit))
 	}
 
 	if limit > p.maxLimit {
-		return p.maxLimit
+		err := fmt.Errorf("%w: maximum is %d", ErrLimitExceeded, p.maxLimit)
+
+		return p.maxLimit, p.strictError(newFieldError(CodeLimitExceeded, param, "", "", raw, err))
 	}
 
-	return limit
+	return limit, nil
 }
 
-func (p *Parser) parseOffset(raw string) int {
-	offset := p.parseInt(raw, p.baseOffset)
+func (p *Parser) parseOffset(param string, raw string) (int, error) {
+	offset, err := p.parseInt(raw, p.baseOffset)
 
-	if offset < 0 {
-		return p.baseOffset
+	if err != nil || offset < 0 {
+		return p.baseOffset, p.strictError(newFieldError(CodeInvalidValue, param, "", "", raw, ErrInvalidOffset))
 	}
 
-	return offset
+	return offset, nil
 }
 
-func (p *Parser) parseInt(raw string, fallback int) int {
+func (p *Parser) parseInt(raw string, fallback int) (int, error) {
 	if len(raw) == 0 {
-		return fallback
+		return fallback, nil
 	}
 
-	value, err := strconv.Atoi(raw)
+	return strconv.Atoi(raw)
+}
 
-	if err != nil {
-		return fallback
+func (p *Parser) strictError(err error) error {
+	if p.strict {
+		return err
 	}
 
-	return value
+	return nil
 }
 
 func (p *Parser) splitClean(raw string, sep string, n int) []string {
@@ -210,10 +559,16 @@ func (p *Parser) splitClean(raw string, sep string, n int) []string {
 	return parts
 }
 
-func (p *Parser) isAllowedField(name string) bool {
-	return slices.ContainsFunc(p.fields, func(f field) bool {
-		return f.name == name
+func (p *Parser) lookupField(name string) (field, bool) {
+	i := slices.IndexFunc(p.fields, func(f field) bool {
+		return slices.Contains(f.names(), name)
 	})
+
+	if i < 0 {
+		return field{}, false
+	}
+
+	return p.fields[i], true
 }
 
 func (p *Parser) isAllowedOrderValue(order string) bool {
@@ -241,3 +596,37 @@ func (p *Parser) WithBaseOffset(base int) *Parser {
 
 	return p
 }
+
+func (p *Parser) WithTimeLayouts(layouts ...string) *Parser {
+	for i, field := range p.fields {
+		if field.typ == timeType {
+			p.fields[i].parseFunc = ParseTime(layouts...)
+		}
+	}
+
+	return p
+}
+
+func (p *Parser) WithMaxListLength(max int) *Parser {
+	p.maxListLength = max
+
+	return p
+}
+
+func (p *Parser) WithFilterSyntax(syntax FilterSyntax) *Parser {
+	p.filterSyntax = syntax
+
+	return p
+}
+
+func (p *Parser) WithStrict(strict bool) *Parser {
+	p.strict = strict
+
+	return p
+}
+
+func (p *Parser) WithOData(odata bool) *Parser {
+	p.odata = odata
+
+	return p
+}
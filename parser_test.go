@@ -1,8 +1,10 @@
 package query_test
 
 import (
+	"database/sql"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/securehaven/query"
 	"github.com/stretchr/testify/assert"
@@ -28,24 +30,14 @@ func TestReadme(t *testing.T) {
 
 	assert.NoError(t, err, "should not return an error")
 
-	// fmt.Printf("%+v", q)
+	posts := []examplePost{
+		{Id: 1, Title: "First"},
+		{Id: 3, Title: "Third", Author: exampleUser{Id: 1, FirstName: "John", LastName: "Doe"}},
+		{Id: 2, Title: "Second"},
+	}
+	expected := []examplePost{posts[2], posts[1]}
 
-	_ = q
-
-	// filtered := q.Filter(Data{
-	// 	Id:        3,
-	// 	FirstName: "John",
-	// 	LastName:  "Doe",
-	// })
-
-	// expected := map[string]any{
-	// 	"first_name": query.NewNull("John", true),
-	// 	"last_name":  query.NewNull("Doe", true),
-	// }
-
-	// if !reflect.DeepEqual(filtered, expected) {
-	// 	t.Errorf("unexpected filtered result: %v", filtered)
-	// }
+	assert.Equal(t, expected, query.Apply(q, posts), "applied items should be equal")
 }
 
 func TestLimit(t *testing.T) {
@@ -124,6 +116,19 @@ func TestSelect(t *testing.T) {
 		assert.Equal(t, expected, q.Select, "selected fields should be equal")
 	})
 
+	t.Run("composite", func(t *testing.T) {
+		values.Set(query.ParamSelect, "author,author.id")
+
+		expected := []query.Selection{
+			{Field: "author", Requested: "author", Column: "author", Composite: true},
+			{Field: "author.id", Requested: "author.id", Column: "author.id"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Selections, "selections should be equal")
+	})
+
 	t.Run("unknown-field", func(t *testing.T) {
 		values.Set(query.ParamSelect, "id,title,created_at")
 
@@ -142,7 +147,7 @@ func TestSort(t *testing.T) {
 		values.Set(query.ParamSort, "id")
 
 		expected := []query.Sorting{
-			{Field: "id", Order: query.OrderAsc},
+			{Field: "id", Order: query.OrderAsc, Requested: "id", Column: "id"},
 		}
 		q, err := parser.Parse(values)
 
@@ -154,7 +159,7 @@ func TestSort(t *testing.T) {
 		values.Set(query.ParamSort, "id:desc")
 
 		expected := []query.Sorting{
-			{Field: "id", Order: query.OrderDesc},
+			{Field: "id", Order: query.OrderDesc, Requested: "id", Column: "id"},
 		}
 		q, err := parser.Parse(values)
 
@@ -166,8 +171,8 @@ func TestSort(t *testing.T) {
 		values.Set(query.ParamSort, "id:desc,author.first_name:asc_nulls_first")
 
 		expected := []query.Sorting{
-			{Field: "id", Order: query.OrderDesc},
-			{Field: "author.first_name", Order: query.OrderAscNullsFirst},
+			{Field: "id", Order: query.OrderDesc, Requested: "id", Column: "id"},
+			{Field: "author.first_name", Order: query.OrderAsc, Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
@@ -179,8 +184,8 @@ func TestSort(t *testing.T) {
 		values.Set(query.ParamSort, "id:desc,author.first_name:asc_nulls_first,created_at:desc_nulls_last")
 
 		expected := []query.Sorting{
-			{Field: "id", Order: query.OrderDesc},
-			{Field: "author.first_name", Order: query.OrderAscNullsFirst},
+			{Field: "id", Order: query.OrderDesc, Requested: "id", Column: "id"},
+			{Field: "author.first_name", Order: query.OrderAsc, Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
@@ -189,13 +194,49 @@ func TestSort(t *testing.T) {
 	})
 }
 
+func TestSortNulls(t *testing.T) {
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+	strictParser := query.MustParser(query.NewParser[exampleEvent]()).WithStrict(true)
+
+	t.Run("nullable", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=deleted_at:asc_nulls_last,archived_at:desc_nulls_first,note:desc_nulls_last")
+		expected := []query.Sorting{
+			{Field: "deleted_at", Order: query.OrderAscNullsLast, Requested: "deleted_at", Column: "deleted_at", Nullable: true},
+			{Field: "archived_at", Order: query.OrderDescNullsFirst, Requested: "archived_at", Column: "archived_at", Nullable: true},
+			{Field: "note", Order: query.OrderDescNullsLast, Requested: "note", Column: "note", Nullable: true},
+		}
+		q, err := strictParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Sortings, "sortings should be equal")
+	})
+
+	t.Run("non-nullable", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=created_at:desc_nulls_first")
+		expected := []query.Sorting{
+			{Field: "created_at", Order: query.OrderDesc, Requested: "created_at", Column: "created_at"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Sortings, "sortings should be equal")
+	})
+
+	t.Run("non-nullable-strict", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=created_at:desc_nulls_first")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOrder, "should reject nulls order")
+	})
+}
+
 func TestFilter(t *testing.T) {
 	t.Run("field-only", func(t *testing.T) {
 		values := make(url.Values, 0)
 		values.Set("author.first_name", "Joe")
 
 		expected := []query.Filtering{
-			{Field: "author.first_name", Filter: query.FilterEquals, Value: "Joe"},
+			{Field: "author.first_name", Filter: query.FilterEquals, Value: "Joe", Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
@@ -208,7 +249,7 @@ func TestFilter(t *testing.T) {
 		values.Set("author.first_name", "neq:Joe")
 
 		expected := []query.Filtering{
-			{Field: "author.first_name", Filter: query.FilterNotEquals, Value: "Joe"},
+			{Field: "author.first_name", Filter: query.FilterNotEquals, Value: "Joe", Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
@@ -222,8 +263,8 @@ func TestFilter(t *testing.T) {
 		values.Set("author.first_name", "neq:Joe")
 
 		expected := []query.Filtering{
-			{Field: "id", Filter: query.FilterEquals, Value: 5},
-			{Field: "author.first_name", Filter: query.FilterNotEquals, Value: "Joe"},
+			{Field: "id", Filter: query.FilterEquals, Value: 5, Requested: "id", Column: "id"},
+			{Field: "author.first_name", Filter: query.FilterNotEquals, Value: "Joe", Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
@@ -234,11 +275,691 @@ func TestFilter(t *testing.T) {
 	t.Run("like", func(t *testing.T) {
 		values, _ := url.ParseQuery("author.first_name=like:Jo%25")
 		expected := []query.Filtering{
-			{Field: "author.first_name", Filter: query.FilterLike, Value: "Jo%"},
+			{Field: "author.first_name", Filter: query.FilterLike, Value: "Jo%", Requested: "author.first_name", Column: "author.first_name"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("range", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gte:18,lte:35")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterGreateThanEquals, Value: 18, Requested: "id", Column: "id"},
+			{Field: "id", Filter: query.FilterLessThanEquals, Value: 35, Requested: "id", Column: "id"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("comma-in-value", func(t *testing.T) {
+		values, _ := url.ParseQuery("author.last_name=" + url.QueryEscape("Smith, John") + "&author.first_name=" + url.QueryEscape("like:J%,A%,neq:Jo"))
+		expected := []query.Filtering{
+			{Field: "author.first_name", Filter: query.FilterLike, Value: "J%,A%", Requested: "author.first_name", Column: "author.first_name"},
+			{Field: "author.first_name", Filter: query.FilterNotEquals, Value: "Jo", Requested: "author.first_name", Column: "author.first_name"},
+			{Field: "author.last_name", Filter: query.FilterEquals, Value: "Smith, John", Requested: "author.last_name", Column: "author.last_name"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.ElementsMatch(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gte:18&id=lte:35")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterGreateThanEquals, Value: 18, Requested: "id", Column: "id"},
+			{Field: "id", Filter: query.FilterLessThanEquals, Value: 35, Requested: "id", Column: "id"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("empty-condition", func(t *testing.T) {
+		for _, raw := range []string{"id=:", "id=,:", "title=:"} {
+			values, _ := url.ParseQuery(raw)
+			q, err := parser.Parse(values)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Empty(t, q.Filterings, "filterings should be empty")
+		}
+	})
+}
+
+func TestStrict(t *testing.T) {
+	strictParser := query.MustParser(query.NewParser[examplePost]()).WithStrict(true)
+
+	t.Run("valid", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=20&offset=5&sort=id:desc&select=id,title&id=gt:1")
+		_, err := strictParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+	})
+
+	t.Run("unknown-operator", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=foo:1")
+		q, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnknownOperator, "should reject unknown operator")
+		assert.Empty(t, q.Filterings, "filterings should be empty")
+	})
+
+	t.Run("unknown-field", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=created_at:desc&select=id,created_at")
+		_, err := strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		assert.ErrorAs(t, err, &parsingError, "should return a parsing error")
+		assert.Len(t, parsingError.Errors, 2, "should report sort and select")
+		assert.ErrorIs(t, err, query.ErrUnknownField, "should reject unknown field")
+	})
+
+	t.Run("unknown-param", func(t *testing.T) {
+		values, _ := url.ParseQuery("nmae=John&id=gt:1&limit=5&page=2&count=exact")
+		q, err := strictParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeUnknownField, fieldError.Code)
+			assert.Equal(t, "nmae", fieldError.Param)
+		}
+
+		assert.Len(t, q.Filterings, 1, "should keep the known filter")
+	})
+
+	t.Run("excluded-param", func(t *testing.T) {
+		values, _ := url.ParseQuery("token=abc&author.first_name=John")
+		_, err := strictParser.Parse(values, "token")
+
+		assert.NoError(t, err, "should not return an error")
+	})
+
+	t.Run("empty-condition", func(t *testing.T) {
+		for _, raw := range []string{"id=:", "id=,:"} {
+			values, _ := url.ParseQuery(raw)
+			q, err := strictParser.Parse(values)
+
+			var fieldError query.FieldError
+
+			if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+				assert.Equal(t, query.CodeInvalidValue, fieldError.Code)
+			}
+
+			assert.ErrorIs(t, err, query.ErrEmptyCondition, "should reject empty condition")
+			assert.Empty(t, q.Filterings, "filterings should be empty")
+		}
+	})
+
+	t.Run("unknown-order", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=id:up")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnknownOrder, "should reject unknown order")
+	})
+
+	t.Run("invalid-limit", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=ten")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidLimit, "should reject invalid limit")
+	})
+
+	t.Run("limit-exceeded", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=1000")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrLimitExceeded, "should reject limit above max")
+	})
+
+	t.Run("invalid-offset", func(t *testing.T) {
+		values, _ := url.ParseQuery("offset=-1")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidOffset, "should reject negative offset")
+	})
+
+	t.Run("lenient", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=foo:1&sort=created_at&limit=ten")
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "unknown operator should be dropped")
+	})
+}
+
+func TestFieldError(t *testing.T) {
+	strictParser := query.MustParser(query.NewParser[examplePost]()).WithStrict(true)
+
+	t.Run("invalid-value", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gt:abc")
+		_, err := parser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeInvalidValue, fieldError.Code)
+			assert.Equal(t, "id", fieldError.Param)
+			assert.Equal(t, "id", fieldError.Field)
+			assert.Equal(t, query.FilterGreaterThan, fieldError.Operator)
+			assert.Equal(t, "abc", fieldError.Value)
+			assert.Equal(t, `invalid value "abc" for "id": invalid syntax`, fieldError.Message)
+		}
+	})
+
+	t.Run("unknown-operator", func(t *testing.T) {
+		values, _ := url.ParseQuery("author.first_name=foo:Joe")
+		_, err := strictParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeUnknownOperator, fieldError.Code)
+			assert.Equal(t, "author.first_name", fieldError.Field)
+			assert.Equal(t, "foo", fieldError.Operator)
+		}
+	})
+
+	t.Run("limit-exceeded", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=500")
+		_, err := strictParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeLimitExceeded, fieldError.Code)
+			assert.Equal(t, query.ParamLimit, fieldError.Param)
+			assert.Equal(t, "500", fieldError.Value)
+		}
+	})
+
+	t.Run("unknown-sort-field", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=created_at:desc")
+		_, err := strictParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeUnknownField, fieldError.Code)
+			assert.Equal(t, query.ParamSort, fieldError.Param)
+			assert.Equal(t, "created_at", fieldError.Field)
+			assert.Equal(t, `sort: unknown field "created_at"`, fieldError.Error())
+		}
+	})
+}
+
+type exampleEvent struct {
+	Id         int                `json:"id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	DeletedAt  *time.Time         `json:"deleted_at"`
+	ArchivedAt sql.NullTime       `json:"archived_at"`
+	Note       query.Null[string] `json:"note"`
+	Priority   sql.NullInt32      `json:"priority"`
+}
+
+func TestTime(t *testing.T) {
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+
+	t.Run("rfc3339", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=gte:2024-01-02T03:04:05Z")
+		expected := []query.Filtering{
+			{Field: "created_at", Filter: query.FilterGreateThanEquals, Value: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Requested: "created_at", Column: "created_at"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("date-only", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=gte:2024-01-01,lt:2024-02-01&deleted_at=lt:2024-03-01")
+		expected := []query.Filtering{
+			{Field: "created_at", Filter: query.FilterGreateThanEquals, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Requested: "created_at", Column: "created_at"},
+			{Field: "created_at", Filter: query.FilterLessThan, Value: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Requested: "created_at", Column: "created_at"},
+			{Field: "deleted_at", Filter: query.FilterLessThan, Value: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Requested: "deleted_at", Column: "deleted_at"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("field-only", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=2024-01-02T03:04:05Z")
+		expected := []query.Filtering{
+			{Field: "created_at", Filter: query.FilterEquals, Value: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Requested: "created_at", Column: "created_at"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=gte:yesterday")
+		_, err := eventParser.Parse(values)
+
+		assert.Error(t, err, "should return an error")
+	})
+
+	t.Run("custom-layouts", func(t *testing.T) {
+		customParser := query.MustParser(query.NewParser[exampleEvent]()).WithTimeLayouts("02.01.2006")
+		values, _ := url.ParseQuery("created_at=01.03.2024")
+		expected := []query.Filtering{
+			{Field: "created_at", Filter: query.FilterEquals, Value: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Requested: "created_at", Column: "created_at"},
+		}
+		q, err := customParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+}
+
+func TestNull(t *testing.T) {
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+
+	t.Run("filter", func(t *testing.T) {
+		values, _ := url.ParseQuery("note=hello&priority=gte:3&archived_at=lt:2024-01-01")
+		expected := []query.Filtering{
+			{Field: "archived_at", Filter: query.FilterLessThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Requested: "archived_at", Column: "archived_at"},
+			{Field: "note", Filter: query.FilterEquals, Value: "hello", Requested: "note", Column: "note"},
+			{Field: "priority", Filter: query.FilterGreateThanEquals, Value: int32(3), Requested: "priority", Column: "priority"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("select", func(t *testing.T) {
+		values, _ := url.ParseQuery("select=note,note.value,priority.valid")
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, []string{"note"}, q.Select, "wrapper internals should not be fields")
+	})
+}
+
+func TestFilterNull(t *testing.T) {
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+	strictParser := query.MustParser(query.NewParser[exampleEvent]()).WithStrict(true)
+
+	t.Run("operators", func(t *testing.T) {
+		values, _ := url.ParseQuery("deleted_at=isnull&note=notnull")
+		expected := []query.Filtering{
+			{Field: "deleted_at", Filter: query.FilterIsNull, Requested: "deleted_at", Column: "deleted_at"},
+			{Field: "note", Filter: query.FilterNotNull, Requested: "note", Column: "note"},
+		}
+		q, err := strictParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("null-token", func(t *testing.T) {
+		values, _ := url.ParseQuery("deleted_at=eq:null&archived_at=neq:null&note=null")
+		expected := []query.Filtering{
+			{Field: "deleted_at", Filter: query.FilterIsNull, Requested: "deleted_at", Column: "deleted_at"},
+			{Field: "archived_at", Filter: query.FilterNotNull, Requested: "archived_at", Column: "archived_at"},
+			{Field: "note", Filter: query.FilterIsNull, Requested: "note", Column: "note"},
+		}
+		q, err := strictParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("non-nullable", func(t *testing.T) {
+		values, _ := url.ParseQuery("author.first_name=null")
+		expected := []query.Filtering{
+			{Field: "author.first_name", Filter: query.FilterEquals, Value: "null", Requested: "author.first_name", Column: "author.first_name"},
 		}
 		q, err := parser.Parse(values)
 
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "null should be a literal for non-nullable fields")
+	})
+
+	t.Run("non-nullable-strict", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=isnull:")
+		_, err := strictParser.Parse(values)
+
+		assert.Error(t, err, "should return an error")
+
+		values, _ = url.ParseQuery("id=notnull:1")
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "unsupported operator should be dropped")
+
+		_, err = strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOperator, "should reject unsupported operator")
+	})
+
+	t.Run("unexpected-value", func(t *testing.T) {
+		values, _ := url.ParseQuery("deleted_at=isnull:2024-01-01")
+		_, err := eventParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnexpectedValue, "should reject a value")
+	})
+}
+
+func TestFilterList(t *testing.T) {
+	t.Run("in", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=in:1|2|3&author.first_name=nin:Joe|Jane")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterIn, Value: []int{1, 2, 3}, Requested: "id", Column: "id"},
+			{Field: "author.first_name", Filter: query.FilterNotIn, Value: []string{"Joe", "Jane"}, Requested: "author.first_name", Column: "author.first_name"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("invalid-element", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=in:1|two")
+		_, err := parser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeInvalidValue, fieldError.Code)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=in:|")
+		_, err := parser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrEmptyList, "should reject empty list")
+	})
+
+	t.Run("too-long", func(t *testing.T) {
+		listParser := query.MustParser(query.NewParser[examplePost]()).WithMaxListLength(2)
+		values, _ := url.ParseQuery("id=in:1|2|3")
+		q, err := listParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeListTooLong, fieldError.Code)
+		}
+
+		assert.ErrorIs(t, err, query.ErrListTooLong, "should reject long list")
+		assert.Empty(t, q.Filterings, "filterings should be empty")
+	})
+}
+
+func TestFilterRange(t *testing.T) {
+	eventParser := query.MustParser(query.NewParser[exampleEvent]())
+
+	t.Run("between", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=between:1|10")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterBetween, Value: query.Range{Lower: 1, Upper: 10}, Requested: "id", Column: "id"},
+		}
+		q, err := parser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("exclusive", func(t *testing.T) {
+		values, _ := url.ParseQuery("created_at=between_exclusive:2024-01-01|2024-02-01")
+		expected := []query.Filtering{
+			{Field: "created_at", Filter: query.FilterBetweenExclusive, Value: query.Range{
+				Lower:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Upper:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+				Exclusive: true,
+			}, Requested: "created_at", Column: "created_at"},
+		}
+		q, err := eventParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("reversed", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=between:10|1")
+		_, err := parser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidRange, "should reject reversed bounds")
+	})
+
+	t.Run("single-bound", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=between:10")
+		_, err := parser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrInvalidRange, "should reject a single bound")
+	})
+
+	t.Run("invalid-bound", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=between:1|ten")
+		_, err := parser.Parse(values)
+
+		assert.Error(t, err, "should reject invalid bound")
+	})
+}
+
+func TestFilterString(t *testing.T) {
+	strictParser := query.MustParser(query.NewParser[examplePost]()).WithStrict(true)
+
+	t.Run("operators", func(t *testing.T) {
+		values, _ := url.ParseQuery("title=contains:50%25,notlike:%25draft%25&author.first_name=ilike:jo%25&author.last_name=startswith:Mc_")
+		expected := []query.Filtering{
+			{Field: "title", Filter: query.FilterContains, Value: "50%", Requested: "title", Column: "title"},
+			{Field: "title", Filter: query.FilterNotLike, Value: "%draft%", Requested: "title", Column: "title"},
+			{Field: "author.first_name", Filter: query.FilterILike, Value: "jo%", Requested: "author.first_name", Column: "author.first_name"},
+			{Field: "author.last_name", Filter: query.FilterStartsWith, Value: "Mc_", Requested: "author.last_name", Column: "author.last_name"},
+		}
+		q, err := strictParser.Parse(values)
+
 		assert.NoError(t, err, "should not return an error")
 		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
 	})
+
+	t.Run("non-string", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=contains:1")
+		_, err := strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOperator, "should reject string operator on int")
+
+		values, _ = url.ParseQuery("id=like:1%25")
+		_, err = strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOperator, "should reject like on int")
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		patterns := map[string]string{
+			query.FilterLike:       `50%_`,
+			query.FilterContains:   `%50\%\_%`,
+			query.FilterIContains:  `%50\%\_%`,
+			query.FilterStartsWith: `50\%\_%`,
+			query.FilterEndsWith:   `%50\%\_`,
+		}
+
+		for filter, expected := range patterns {
+			pattern, ok := query.Filtering{Filter: filter, Value: "50%_"}.LikePattern()
+
+			assert.True(t, ok, "should return a pattern")
+			assert.Equal(t, expected, pattern, "pattern should be equal")
+		}
+
+		_, ok := query.Filtering{Filter: query.FilterEquals, Value: "x"}.LikePattern()
+
+		assert.False(t, ok, "eq should not return a pattern")
+	})
+}
+
+type exampleTask struct {
+	Id       int    `json:"id" query:"ops=eq,in"`
+	Title    string `json:"title" query:"sort=false"`
+	Done     bool   `json:"done"`
+	Internal string `json:"internal" query:"filter=false;select=false"`
+}
+
+func TestTag(t *testing.T) {
+	taskParser := query.MustParser(query.NewParser[exampleTask]())
+	strictParser := query.MustParser(query.NewParser[exampleTask]()).WithStrict(true)
+
+	t.Run("ops", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=in:1|2&done=true")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterIn, Value: []int{1, 2}, Requested: "id", Column: "id"},
+			{Field: "done", Filter: query.FilterEquals, Value: true, Requested: "done", Column: "done"},
+		}
+		q, err := strictParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("disallowed-ops", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gt:1&done=gt:false")
+		q, err := taskParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "disallowed operators should be dropped")
+
+		_, err = strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		if assert.ErrorAs(t, err, &parsingError, "should return a parsing error") {
+			assert.Len(t, parsingError.Errors, 2, "should report both operators")
+		}
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOperator, "should reject disallowed operators")
+	})
+
+	t.Run("not-filterable", func(t *testing.T) {
+		values, _ := url.ParseQuery("internal=x")
+		q, err := taskParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Empty(t, q.Filterings, "filterings should be empty")
+
+		_, err = strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrNotFilterable, "should reject non-filterable field")
+	})
+
+	t.Run("not-sortable", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=title,id")
+		q, err := taskParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, []query.Sorting{{Field: "id", Order: query.OrderAsc, Requested: "id", Column: "id"}}, q.Sortings, "sortings should be equal")
+
+		_, err = strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrNotSortable, "should reject non-sortable field")
+	})
+
+	t.Run("not-selectable", func(t *testing.T) {
+		values, _ := url.ParseQuery("select=id,internal")
+		q, err := taskParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, []string{"id"}, q.Select, "selected fields should be equal")
+
+		_, err = strictParser.Parse(values)
+
+		assert.ErrorIs(t, err, query.ErrNotSelectable, "should reject non-selectable field")
+	})
+}
+
+type exampleOwner struct {
+	Id   int    `json:"id"`
+	Name string `json:"name" query:"alias=full_name;column=display_name"`
+}
+
+type exampleAccount struct {
+	Id    int          `json:"id" query:"column=account_id"`
+	Email string       `json:"email" query:"alias=mail,e_mail;column=email_address"`
+	Plan  string       `json:"plan_name" query:"name=plan"`
+	Owner exampleOwner `json:"owner" query:"alias=user;column=owners"`
+}
+
+func TestAlias(t *testing.T) {
+	accountParser := query.MustParser(query.NewParser[exampleAccount]())
+
+	t.Run("filter", func(t *testing.T) {
+		values, _ := url.ParseQuery("mail=a@b.c&id=1&user.full_name=Joe&plan=pro")
+		expected := []query.Filtering{
+			{Field: "id", Filter: query.FilterEquals, Value: 1, Requested: "id", Column: "account_id"},
+			{Field: "email", Filter: query.FilterEquals, Value: "a@b.c", Requested: "mail", Column: "email_address"},
+			{Field: "plan", Filter: query.FilterEquals, Value: "pro", Requested: "plan", Column: "plan"},
+			{Field: "owner.name", Filter: query.FilterEquals, Value: "Joe", Requested: "user.full_name", Column: "owners.display_name"},
+		}
+		q, err := accountParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Filterings, "filterings should be equal")
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		values, _ := url.ParseQuery("sort=e_mail:desc,owner.full_name")
+		expected := []query.Sorting{
+			{Field: "email", Order: query.OrderDesc, Requested: "e_mail", Column: "email_address"},
+			{Field: "owner.name", Order: query.OrderAsc, Requested: "owner.full_name", Column: "owners.display_name"},
+		}
+		q, err := accountParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Sortings, "sortings should be equal")
+	})
+
+	t.Run("select", func(t *testing.T) {
+		values, _ := url.ParseQuery("select=id,mail,user.name,plan_name")
+		expected := []query.Selection{
+			{Field: "id", Requested: "id", Column: "account_id"},
+			{Field: "email", Requested: "mail", Column: "email_address"},
+			{Field: "owner.name", Requested: "user.name", Column: "owners.display_name"},
+		}
+		q, err := accountParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Selections, "selections should be equal")
+		assert.Equal(t, []string{"id", "email", "owner.name"}, q.Select, "select should contain canonical names")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		values, _ := url.ParseQuery("mail=contains:%25&id=x")
+		_, err := accountParser.Parse(values)
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, "id", fieldError.Param)
+		}
+
+		values, _ = url.ParseQuery("user.id=x")
+		_, err = accountParser.Parse(values)
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, "user.id", fieldError.Param)
+			assert.Equal(t, "owner.id", fieldError.Field)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		type example struct {
+			Email string `json:"email"`
+			Mail  string `json:"mail" query:"alias=email"`
+		}
+
+		_, err := query.NewParser[example]()
+
+		assert.ErrorIs(t, err, query.ErrDuplicateField, "should reject duplicate names")
+	})
 }
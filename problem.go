@@ -0,0 +1,81 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+var (
+	ProblemType   = "about:blank"
+	ProblemDetail = "The request contains invalid query parameters."
+)
+
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+type InvalidParam struct {
+	Name     string `json:"name"`
+	Reason   string `json:"reason"`
+	Field    string `json:"field,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+func (e ParsingError) Problem() Problem {
+	invalidParams := make([]InvalidParam, 0, len(e.Errors))
+	details := make([]string, 0)
+
+	for _, err := range e.Errors {
+		var fieldError FieldError
+
+		if !errors.As(err, &fieldError) {
+			details = append(details, err.Error())
+			continue
+		}
+
+		invalidParams = append(invalidParams, InvalidParam{
+			Name:     fieldError.Param,
+			Reason:   fieldError.Message,
+			Field:    fieldError.Field,
+			Operator: fieldError.Operator,
+			Value:    fieldError.Value,
+			Code:     fieldError.Code,
+		})
+	}
+
+	detail := ProblemDetail
+
+	if len(details) > 0 {
+		detail = strings.Join(details, "; ")
+	}
+
+	return Problem{
+		Type:          ProblemType,
+		Title:         http.StatusText(http.StatusBadRequest),
+		Status:        http.StatusBadRequest,
+		Detail:        detail,
+		InvalidParams: invalidParams,
+	}
+}
+
+func (e ParsingError) WriteProblem(w http.ResponseWriter) error {
+	return e.Problem().Write(w)
+}
+
+func (p Problem) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+
+	return json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,78 @@
+package query_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblem(t *testing.T) {
+	strictParser := query.MustParser(query.NewParser[examplePost]()).WithStrict(true)
+
+	t.Run("invalid-params", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=gt:abc&sort=created_at")
+		_, err := strictParser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		if !assert.ErrorAs(t, err, &parsingError, "should return a parsing error") {
+			return
+		}
+
+		problem := parsingError.Problem()
+
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, query.ProblemType, problem.Type)
+		assert.Equal(t, query.ProblemDetail, problem.Detail)
+		assert.ElementsMatch(t, []query.InvalidParam{
+			{Name: "sort", Reason: `unknown field "created_at"`, Field: "created_at", Value: "created_at", Code: query.CodeUnknownField},
+			{Name: "id", Reason: `invalid value "abc" for "id": invalid syntax`, Field: "id", Operator: query.FilterGreaterThan, Value: "abc", Code: query.CodeInvalidValue},
+		}, problem.InvalidParams)
+	})
+
+	t.Run("plain-error", func(t *testing.T) {
+		parsingError := query.ParsingError{Errors: []error{errors.New("something failed"), errors.New("something else failed")}}
+		problem := parsingError.Problem()
+
+		assert.Empty(t, problem.InvalidParams, "invalid params should be empty")
+		assert.Equal(t, "something failed; something else failed", problem.Detail, "detail should be equal")
+	})
+
+	t.Run("write", func(t *testing.T) {
+		values, _ := url.ParseQuery("id=abc")
+		_, err := parser.Parse(values)
+
+		var parsingError query.ParsingError
+
+		if !assert.ErrorAs(t, err, &parsingError, "should return a parsing error") {
+			return
+		}
+
+		recorder := httptest.NewRecorder()
+
+		assert.NoError(t, parsingError.WriteProblem(recorder), "should not return an error")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, query.ProblemContentType, recorder.Header().Get("Content-Type"))
+
+		var body map[string]any
+
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body), "body should be valid json")
+		assert.Equal(t, "Bad Request", body["title"])
+		assert.Equal(t, []any{
+			map[string]any{
+				"name":     "id",
+				"reason":   `invalid value "abc" for "id": invalid syntax`,
+				"field":    "id",
+				"operator": query.FilterEquals,
+				"value":    "abc",
+				"code":     query.CodeInvalidValue,
+			},
+		}, body["invalid-params"])
+	})
+}
@@ -3,7 +3,38 @@ package query
 type Query struct {
 	Limit      int
 	Offset     int
+	Page       int
 	Select     []string
+	Selections []Selection
 	Sortings   []Sorting
 	Filterings []Filtering
+	Where      Expr
+	Count      CountMode
+	Cursor     *Cursor
+}
+
+type CountMode string
+
+const (
+	CountNone      CountMode = ""
+	CountExact     CountMode = "exact"
+	CountEstimated CountMode = "estimated"
+	CountOnly      CountMode = "only"
+)
+
+type Selection struct {
+	Field     string
+	Requested string
+	Column    string
+	Composite bool
+}
+
+func selectionFields(selections []Selection) []string {
+	fields := make([]string, len(selections))
+
+	for i, selection := range selections {
+		fields[i] = selection.Field
+	}
+
+	return fields
 }
@@ -0,0 +1,29 @@
+package query
+
+const DefaultForeignKey = "id"
+
+type Relation struct {
+	Path       string
+	Table      string
+	LocalKey   string
+	ForeignKey string
+}
+
+func (p *Parser) Relations() []Relation {
+	relations := make([]Relation, 0)
+
+	for _, f := range p.fields {
+		if len(f.join.table) == 0 {
+			continue
+		}
+
+		relations = append(relations, Relation{
+			Path:       f.column,
+			Table:      f.join.table,
+			LocalKey:   f.join.localKey,
+			ForeignKey: f.join.foreignKey,
+		})
+	}
+
+	return relations
+}
@@ -0,0 +1,297 @@
+package query
+
+import (
+	"strings"
+)
+
+var (
+	rsqlSymbols             = []string{";", ",", "(", ")"}
+	rsqlSelectorDelimiters  = "();,=!<>"
+	rsqlArgumentDelimiters  = "();,"
+	rsqlComparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+	rsqlFilterValues = map[string]string{
+		"==":    FilterEquals,
+		"!=":    FilterNotEquals,
+		"<":     FilterLessThan,
+		"<=":    FilterLessThanEquals,
+		">":     FilterGreaterThan,
+		">=":    FilterGreateThanEquals,
+		"=lt=":  FilterLessThan,
+		"=le=":  FilterLessThanEquals,
+		"=gt=":  FilterGreaterThan,
+		"=ge=":  FilterGreateThanEquals,
+		"=ne=":  FilterNotEquals,
+		"=in=":  FilterIn,
+		"=out=": FilterNotIn,
+	}
+)
+
+type rsqlParser struct {
+	parser *Parser
+	lexer  *lexer
+	depth  int
+}
+
+func (p *Parser) parseRSQL(raw string) (Expr, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	r := rsqlParser{
+		parser: p,
+		lexer:  newLexer(raw, rsqlSymbols, rsqlSelectorDelimiters, `'"`, true),
+	}
+
+	expr, err := r.parse()
+
+	return expr, wrapSyntaxError(ParamFilter, raw, err)
+}
+
+func (r *rsqlParser) parse() (Expr, error) {
+	expr, err := r.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := r.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t.kind != tokenEOF {
+		return nil, r.lexer.errorf(t.pos, "unexpected %s", t)
+	}
+
+	return expr, nil
+}
+
+func (r *rsqlParser) parseOr() (Expr, error) {
+	return r.parseBinary(",", "or", r.parseAnd, newOrExpr)
+}
+
+func (r *rsqlParser) parseAnd() (Expr, error) {
+	return r.parseBinary(";", "and", r.parseConstraint, newAndExpr)
+}
+
+func (r *rsqlParser) parseBinary(symbol string, keyword string, operand func() (Expr, error), combine func([]Expr) Expr) (Expr, error) {
+	expr, err := operand()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{expr}
+
+	for {
+		t, err := r.lexer.peek()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.is(tokenSymbol, symbol) && !t.isWord(keyword) {
+			break
+		}
+
+		r.lexer.next()
+
+		if expr, err = operand(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return combine(exprs), nil
+}
+
+func (r *rsqlParser) parseConstraint() (Expr, error) {
+	t, err := r.lexer.peek()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.is(tokenSymbol, "(") {
+		return r.parseComparison()
+	}
+
+	if r.depth >= maxExprDepth {
+		return nil, r.lexer.errorf(t.pos, "expression is nested too deeply")
+	}
+
+	r.lexer.next()
+	r.depth++
+
+	expr, err := r.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.lexer.expect(tokenSymbol, ")"); err != nil {
+		return nil, err
+	}
+
+	r.depth--
+
+	return expr, nil
+}
+
+func (r *rsqlParser) parseComparison() (Expr, error) {
+	selector, err := r.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if selector.kind != tokenWord {
+		return nil, r.lexer.errorf(selector.pos, "expected selector, got %s", selector)
+	}
+
+	pos := r.lexer.pos
+	operator, ok := r.scanOperator()
+
+	if !ok {
+		return nil, r.lexer.errorf(pos, "expected comparison operator")
+	}
+
+	filter, ok := rsqlFilterValues[operator]
+
+	if !ok {
+		filter = strings.ToLower(strings.Trim(operator, "="))
+	}
+
+	r.lexer.delimiters = rsqlArgumentDelimiters
+	args, literal, err := r.parseArguments()
+	r.lexer.delimiters = rsqlSelectorDelimiters
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter, args = r.normalize(selector.text, filter, args, literal)
+	filtering, err := r.parser.newExprFiltering(ParamFilter, selector.text, filter, args, literal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filtering, nil
+}
+
+func (r *rsqlParser) scanOperator() (string, bool) {
+	l := r.lexer
+
+	for l.pos < len(l.input) && l.isSpace(l.pos) {
+		l.pos++
+	}
+
+	rest := l.input[l.pos:]
+
+	for _, operator := range rsqlComparisonOperators {
+		if strings.HasPrefix(rest, operator) {
+			l.pos += len(operator)
+
+			return operator, true
+		}
+	}
+
+	if !strings.HasPrefix(rest, "=") {
+		return "", false
+	}
+
+	end := strings.IndexByte(rest[1:], '=')
+
+	if end <= 0 {
+		return "", false
+	}
+
+	name := rest[1 : end+1]
+
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && c != '_' {
+			return "", false
+		}
+	}
+
+	l.pos += end + 2
+
+	return rest[:end+2], true
+}
+
+func (r *rsqlParser) parseArguments() ([]string, bool, error) {
+	t, err := r.lexer.peek()
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !t.is(tokenSymbol, "(") {
+		value, err := r.parseArgument()
+
+		return []string{value.text}, value.kind == tokenWord, err
+	}
+
+	r.lexer.next()
+
+	args := make([]string, 0)
+
+	for {
+		value, err := r.parseArgument()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		args = append(args, value.text)
+
+		t, err := r.lexer.next()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if t.is(tokenSymbol, ")") {
+			return args, false, nil
+		}
+
+		if !t.is(tokenSymbol, ",") {
+			return nil, false, r.lexer.errorf(t.pos, "expected \",\" or \")\", got %s", t)
+		}
+	}
+}
+
+func (r *rsqlParser) parseArgument() (token, error) {
+	t, err := r.lexer.next()
+
+	if err != nil {
+		return t, err
+	}
+
+	if t.kind != tokenWord && t.kind != tokenString {
+		return t, r.lexer.errorf(t.pos, "expected argument, got %s", t)
+	}
+
+	return t, nil
+}
+
+func (r *rsqlParser) normalize(selector string, filter string, args []string, literal bool) (string, []string) {
+	switch filter {
+	case FilterIsNull:
+		if len(args) == 1 && strings.EqualFold(args[0], "false") {
+			return FilterNotNull, nil
+		}
+
+		return FilterIsNull, nil
+	case FilterEquals, FilterNotEquals:
+		if literal && len(args) == 1 {
+			filter, args[0] = r.parser.wildcardFilter(selector, filter, args[0])
+		}
+	}
+
+	return filter, args
+}
@@ -0,0 +1,94 @@
+package query_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+var rsqlParser = query.MustParser(query.NewParser[exampleTicket]()).WithFilterSyntax(query.FilterSyntaxRSQL)
+
+func TestRSQL(t *testing.T) {
+	t.Run("comparisons", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"status==open;priority=gt=3,id=in=(1,2)"}}
+		expected := query.OrExpr{Exprs: []query.Expr{
+			query.AndExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "status", Filter: query.FilterEquals, Value: "open", Requested: "status", Column: "status"},
+				query.Filtering{Field: "priority", Filter: query.FilterGreaterThan, Value: 3, Requested: "priority", Column: "priority"},
+			}},
+			query.Filtering{Field: "id", Filter: query.FilterIn, Value: []int{1, 2}, Requested: "id", Column: "id"},
+		}}
+		q, err := rsqlParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("operators", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"priority<=2;priority>=1;priority!=5;id=out=(4,9);priority=le=3;priority=between=(1,5)"}}
+		expected := query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "priority", Filter: query.FilterLessThanEquals, Value: 2, Requested: "priority", Column: "priority"},
+			query.Filtering{Field: "priority", Filter: query.FilterGreateThanEquals, Value: 1, Requested: "priority", Column: "priority"},
+			query.Filtering{Field: "priority", Filter: query.FilterNotEquals, Value: 5, Requested: "priority", Column: "priority"},
+			query.Filtering{Field: "id", Filter: query.FilterNotIn, Value: []int{4, 9}, Requested: "id", Column: "id"},
+			query.Filtering{Field: "priority", Filter: query.FilterLessThanEquals, Value: 3, Requested: "priority", Column: "priority"},
+			query.Filtering{Field: "priority", Filter: query.FilterBetween, Value: query.Range{Lower: 1, Upper: 5}, Requested: "priority", Column: "priority"},
+		}}
+		q, err := rsqlParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("groups-and-quotes", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {`(status=="in progress",status=='it\'s') and assignee=isnull=false`}}
+		expected := query.AndExpr{Exprs: []query.Expr{
+			query.OrExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "status", Filter: query.FilterEquals, Value: "in progress", Requested: "status", Column: "status"},
+				query.Filtering{Field: "status", Filter: query.FilterEquals, Value: "it's", Requested: "status", Column: "status"},
+			}},
+			query.Filtering{Field: "assignee", Filter: query.FilterNotNull, Requested: "assignee", Column: "assignee"},
+		}}
+		q, err := rsqlParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("wildcards", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"status==op*;status!=*_x;assignee==null"}}
+		expected := query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "status", Filter: query.FilterLike, Value: "op%", Requested: "status", Column: "status"},
+			query.Filtering{Field: "status", Filter: query.FilterNotLike, Value: `%\_x`, Requested: "status", Column: "status"},
+			query.Filtering{Field: "assignee", Filter: query.FilterIsNull, Requested: "assignee", Column: "assignee"},
+		}}
+		q, err := rsqlParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := rsqlParser.Parse(url.Values{query.ParamFilter: {"name==John"}})
+
+		assert.ErrorIs(t, err, query.ErrUnknownField, "should reject unknown selector")
+
+		_, err = rsqlParser.Parse(url.Values{query.ParamFilter: {"status=foo=x"}})
+
+		assert.ErrorIs(t, err, query.ErrUnknownOperator, "should reject unknown operator")
+
+		_, err = rsqlParser.Parse(url.Values{query.ParamFilter: {"status~x"}})
+
+		var syntaxError query.SyntaxError
+
+		if assert.ErrorAs(t, err, &syntaxError, "should return a syntax error") {
+			assert.Equal(t, 8, syntaxError.Pos)
+		}
+
+		_, err = rsqlParser.Parse(url.Values{query.ParamFilter: {"(status==a;id==1"}})
+
+		assert.ErrorAs(t, err, &syntaxError, "should return a syntax error")
+	})
+}
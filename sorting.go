@@ -1,14 +1,17 @@
 package query
 
 type Sorting struct {
-	Field string
-	Order string
+	Field     string
+	Order     string
+	Requested string
+	Column    string
+	Nullable  bool
 }
 
 const (
 	OrderAsc           = "asc"
 	OrderAscNullsFirst = "asc_nulls_first"
-	OrderAscNullsLast  = "desc_nulls_last"
+	OrderAscNullsLast  = "asc_nulls_last"
 
 	OrderDesc           = "desc"
 	OrderDescNullsFirst = "desc_nulls_first"
@@ -25,3 +28,12 @@ var (
 		OrderDescNullsLast,
 	}
 )
+
+var (
+	nullsOrderValues = map[string]string{
+		OrderAscNullsFirst:  OrderAsc,
+		OrderAscNullsLast:   OrderAsc,
+		OrderDescNullsFirst: OrderDesc,
+		OrderDescNullsLast:  OrderDesc,
+	}
+)
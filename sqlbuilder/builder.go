@@ -0,0 +1,515 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/securehaven/query"
+)
+
+var (
+	ErrUnsupportedFilter = errors.New("unsupported filter")
+	ErrUnsupportedOrder  = errors.New("unsupported sort order")
+	ErrUnsupportedExpr   = errors.New("unsupported expression")
+	ErrInvalidValue      = errors.New("invalid filter value")
+	ErrInvalidCursor     = errors.New("cursor does not match the sort")
+	ErrMissingRelation   = errors.New("nested column has no relation")
+	ErrCompositeColumn   = errors.New("struct field is not a column")
+)
+
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+type Fragments struct {
+	Columns string
+	Joins   string
+	Where   string
+	OrderBy string
+	Limit   string
+	Args    []any
+}
+
+type Builder struct {
+	table     string
+	dialect   Dialect
+	relations []query.Relation
+}
+
+type writer struct {
+	dialect   Dialect
+	table     string
+	relations []query.Relation
+	joined    map[string]bool
+	args      []any
+}
+
+const (
+	sqlTrue  = "1 = 1"
+	sqlFalse = "1 = 0"
+)
+
+func NewBuilder(table string) *Builder {
+	return &Builder{
+		table:   table,
+		dialect: Postgres{},
+	}
+}
+
+func (b *Builder) Select(q query.Query) (Statement, error) {
+	fragments, err := b.Build(q)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	return Statement{
+		SQL:  joinClauses("SELECT "+fragments.Columns, "FROM "+quoteColumn(b.dialect, b.table), fragments.Joins, fragments.Where, fragments.OrderBy, fragments.Limit),
+		Args: fragments.Args,
+	}, nil
+}
+
+func (b *Builder) Count(q query.Query) (Statement, error) {
+	q = q.ForCount()
+	w, err := b.writer(q)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	where, err := w.where(q)
+
+	if err != nil {
+		return Statement{}, err
+	}
+
+	return Statement{
+		SQL:  joinClauses("SELECT COUNT(*) FROM "+quoteColumn(b.dialect, b.table), w.joins(), where),
+		Args: w.args,
+	}, nil
+}
+
+func (b *Builder) Build(q query.Query) (Fragments, error) {
+	w, err := b.writer(q)
+
+	if err != nil {
+		return Fragments{}, err
+	}
+
+	where, err := w.where(q)
+
+	if err != nil {
+		return Fragments{}, err
+	}
+
+	orderBy, err := w.orderBy(q)
+
+	if err != nil {
+		return Fragments{}, err
+	}
+
+	return Fragments{
+		Columns: w.columns(q),
+		Joins:   w.joins(),
+		Where:   where,
+		OrderBy: orderBy,
+		Limit:   w.limit(q, len(orderBy) > 0),
+		Args:    w.args,
+	}, nil
+}
+
+func (w *writer) columns(q query.Query) string {
+	if len(q.Selections) == 0 {
+		if len(w.joined) > 0 {
+			return quoteColumn(w.dialect, w.table) + ".*"
+		}
+
+		return "*"
+	}
+
+	columns := make([]string, len(q.Selections))
+
+	for i, selection := range q.Selections {
+		columns[i] = w.column(selection.Column, selection.Field)
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+func (w *writer) where(q query.Query) (string, error) {
+	conditions := make([]string, 0, len(q.Filterings)+2)
+
+	for _, filtering := range q.Filterings {
+		condition, err := w.filtering(filtering)
+
+		if err != nil {
+			return "", err
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if q.Where != nil {
+		condition, err := w.expr(q.Where)
+
+		if err != nil {
+			return "", err
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if q.Cursor != nil {
+		condition, err := w.keyset(q.Sortings, q.Cursor)
+
+		if err != nil {
+			return "", err
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), nil
+}
+
+func (w *writer) expr(expr query.Expr) (string, error) {
+	switch e := expr.(type) {
+	case query.Filtering:
+		return w.filtering(e)
+	case query.AndExpr:
+		return w.exprs(e.Exprs, " AND ", sqlTrue)
+	case query.OrExpr:
+		return w.exprs(e.Exprs, " OR ", sqlFalse)
+	case query.NotExpr:
+		condition, err := w.expr(e.Expr)
+
+		if err != nil {
+			return "", err
+		}
+
+		return "NOT (" + condition + ")", nil
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedExpr, expr)
+}
+
+func (w *writer) exprs(exprs []query.Expr, separator string, empty string) (string, error) {
+	if len(exprs) == 0 {
+		return empty, nil
+	}
+
+	conditions := make([]string, len(exprs))
+
+	for i, expr := range exprs {
+		condition, err := w.expr(expr)
+
+		if err != nil {
+			return "", err
+		}
+
+		conditions[i] = condition
+	}
+
+	return "(" + strings.Join(conditions, separator) + ")", nil
+}
+
+func (w *writer) filtering(f query.Filtering) (string, error) {
+	column := w.column(f.Column, f.Field)
+
+	switch f.Filter {
+	case query.FilterEquals:
+		return column + " = " + w.arg(f.Value), nil
+	case query.FilterNotEquals:
+		return column + " <> " + w.arg(f.Value), nil
+	case query.FilterLessThan:
+		return column + " < " + w.arg(f.Value), nil
+	case query.FilterLessThanEquals:
+		return column + " <= " + w.arg(f.Value), nil
+	case query.FilterGreaterThan:
+		return column + " > " + w.arg(f.Value), nil
+	case query.FilterGreateThanEquals:
+		return column + " >= " + w.arg(f.Value), nil
+	case query.FilterIsNull:
+		return column + " IS NULL", nil
+	case query.FilterNotNull:
+		return column + " IS NOT NULL", nil
+	case query.FilterLike, query.FilterContains, query.FilterStartsWith, query.FilterEndsWith:
+		return w.like(column, f, false, false)
+	case query.FilterILike, query.FilterIContains:
+		return w.like(column, f, true, false)
+	case query.FilterNotLike:
+		return w.like(column, f, false, true)
+	case query.FilterIn:
+		return w.list(column, "IN", sqlFalse, f)
+	case query.FilterNotIn:
+		return w.list(column, "NOT IN", sqlTrue, f)
+	case query.FilterBetween, query.FilterBetweenExclusive:
+		return w.between(column, f)
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFilter, f.Filter)
+}
+
+func (w *writer) like(column string, f query.Filtering, insensitive bool, negated bool) (string, error) {
+	value, ok := f.Value.(string)
+
+	if !ok {
+		return "", fmt.Errorf("%w: %q expects a string", ErrInvalidValue, f.Filter)
+	}
+
+	switch f.Filter {
+	case query.FilterContains, query.FilterIContains:
+		value = "%" + w.dialect.EscapeLike(value) + "%"
+	case query.FilterStartsWith:
+		value = w.dialect.EscapeLike(value) + "%"
+	case query.FilterEndsWith:
+		value = "%" + w.dialect.EscapeLike(value)
+	}
+
+	return w.dialect.Like(column, w.arg(w.dialect.LikeValue(value, insensitive)), insensitive, negated), nil
+}
+
+func (w *writer) list(column string, operator string, empty string, f query.Filtering) (string, error) {
+	value := reflect.ValueOf(f.Value)
+
+	if value.Kind() != reflect.Slice {
+		return "", fmt.Errorf("%w: %q expects a list", ErrInvalidValue, f.Filter)
+	}
+
+	if value.Len() == 0 {
+		return empty, nil
+	}
+
+	placeholders := make([]string, value.Len())
+
+	for i := range placeholders {
+		placeholders[i] = w.arg(value.Index(i).Interface())
+	}
+
+	return column + " " + operator + " (" + strings.Join(placeholders, ", ") + ")", nil
+}
+
+func (w *writer) between(column string, f query.Filtering) (string, error) {
+	r, ok := f.Value.(query.Range)
+
+	if !ok {
+		return "", fmt.Errorf("%w: %q expects a range", ErrInvalidValue, f.Filter)
+	}
+
+	if f.Filter == query.FilterBetweenExclusive || r.Exclusive {
+		return "(" + column + " > " + w.arg(r.Lower) + " AND " + column + " < " + w.arg(r.Upper) + ")", nil
+	}
+
+	return column + " BETWEEN " + w.arg(r.Lower) + " AND " + w.arg(r.Upper), nil
+}
+
+func (w *writer) keyset(sortings []query.Sorting, cursor *query.Cursor) (string, error) {
+	if len(sortings) == 0 || len(cursor.Keys) != len(sortings) {
+		return "", ErrInvalidCursor
+	}
+
+	alternatives := make([]string, 0, len(sortings))
+
+	for i := range sortings {
+		conditions := make([]string, 0, i+1)
+
+		for j, sorting := range sortings[:i] {
+			column := w.column(sorting.Column, sorting.Field)
+
+			if cursor.Keys[j] == nil {
+				conditions = append(conditions, column+" IS NULL")
+			} else {
+				conditions = append(conditions, column+" = "+w.arg(cursor.Keys[j]))
+			}
+		}
+
+		order, ok := cursorOrder(sortings[i].Order, cursor.Direction)
+
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnsupportedOrder, sortings[i].Order)
+		}
+
+		column := w.column(sortings[i].Column, sortings[i].Field)
+
+		if after, ok := w.after(column, orderSpecs[order], sortings[i].Nullable, cursor.Keys[i]); ok {
+			alternatives = append(alternatives, joinConditions(append(conditions, after)))
+		}
+	}
+
+	if len(alternatives) == 0 {
+		return sqlFalse, nil
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", nil
+}
+
+func (w *writer) after(column string, spec orderSpec, nullable bool, key any) (string, bool) {
+	nullsFirst := spec.nulls == NullsFirst || (spec.nulls == NullsDefault && spec.descending == w.dialect.NullsSortHigh())
+
+	if key == nil {
+		if !nullsFirst {
+			return "", false
+		}
+
+		return column + " IS NOT NULL", true
+	}
+
+	operator := " > "
+
+	if spec.descending {
+		operator = " < "
+	}
+
+	condition := column + operator + w.arg(key)
+
+	if nullable && !nullsFirst {
+		return "(" + condition + " OR " + column + " IS NULL)", true
+	}
+
+	return condition, true
+}
+
+func (w *writer) limit(q query.Query, ordered bool) string {
+	hasOffset := q.Offset > 0 && q.Cursor == nil
+
+	if q.Limit <= 0 && !hasOffset {
+		return ""
+	}
+
+	limit, offset := "", ""
+
+	if q.Limit > 0 {
+		limit = w.arg(q.Limit)
+	}
+
+	if hasOffset {
+		offset = w.arg(q.Offset)
+	}
+
+	return w.dialect.Limit(limit, offset, ordered)
+}
+
+func (w *writer) arg(v any) string {
+	w.args = append(w.args, v)
+
+	return w.dialect.Placeholder(len(w.args))
+}
+
+func (w *writer) orderBy(q query.Query) (string, error) {
+	if len(q.Sortings) == 0 {
+		return "", nil
+	}
+
+	direction := query.CursorNext
+
+	if q.Cursor != nil {
+		direction = q.Cursor.Direction
+	}
+
+	orders := make([]string, len(q.Sortings))
+
+	for i, sorting := range q.Sortings {
+		order, ok := cursorOrder(sorting.Order, direction)
+
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnsupportedOrder, sorting.Order)
+		}
+
+		spec := orderSpecs[order]
+		orders[i] = w.dialect.Order(w.column(sorting.Column, sorting.Field), spec.descending, spec.nulls)
+	}
+
+	return "ORDER BY " + strings.Join(orders, ", "), nil
+}
+
+type orderSpec struct {
+	descending bool
+	nulls      Nulls
+}
+
+var (
+	orderSpecs = map[string]orderSpec{
+		query.OrderAsc:            {descending: false, nulls: NullsDefault},
+		query.OrderAscNullsFirst:  {descending: false, nulls: NullsFirst},
+		query.OrderAscNullsLast:   {descending: false, nulls: NullsLast},
+		query.OrderDesc:           {descending: true, nulls: NullsDefault},
+		query.OrderDescNullsFirst: {descending: true, nulls: NullsFirst},
+		query.OrderDescNullsLast:  {descending: true, nulls: NullsLast},
+	}
+
+	reversedOrders = map[string]string{
+		query.OrderAsc:            query.OrderDesc,
+		query.OrderAscNullsFirst:  query.OrderDescNullsLast,
+		query.OrderAscNullsLast:   query.OrderDescNullsFirst,
+		query.OrderDesc:           query.OrderAsc,
+		query.OrderDescNullsFirst: query.OrderAscNullsLast,
+		query.OrderDescNullsLast:  query.OrderAscNullsFirst,
+	}
+)
+
+func cursorOrder(order string, direction query.CursorDirection) (string, bool) {
+	if direction == query.CursorPrev {
+		order = reversedOrders[order]
+	}
+
+	_, ok := orderSpecs[order]
+
+	return order, ok
+}
+
+func quoteColumn(d Dialect, column string) string {
+	parts := strings.Split(column, query.SeparatorSelector)
+
+	for i, part := range parts {
+		parts[i] = d.QuoteIdentifier(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func columnName(column string, field string) string {
+	if len(column) == 0 {
+		return field
+	}
+
+	return column
+}
+
+func joinConditions(conditions []string) string {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")"
+}
+
+func joinClauses(clauses ...string) string {
+	parts := make([]string, 0, len(clauses))
+
+	for _, clause := range clauses {
+		if len(clause) > 0 {
+			parts = append(parts, clause)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (b *Builder) WithRelations(relations []query.Relation) *Builder {
+	b.relations = relations
+
+	return b
+}
+
+func (b *Builder) WithDialect(dialect Dialect) *Builder {
+	b.dialect = dialect
+
+	return b
+}
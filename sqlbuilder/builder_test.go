@@ -0,0 +1,184 @@
+package sqlbuilder_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/securehaven/query/sqlbuilder"
+	"github.com/stretchr/testify/assert"
+)
+
+type exampleAuthor struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type exampleArticle struct {
+	Id          int                `json:"id"`
+	Title       string             `json:"title" query:"column=headline"`
+	Status      string             `json:"status"`
+	Rating      query.Null[int]    `json:"rating"`
+	PublishedAt query.Null[string] `json:"published_at"`
+	Author      exampleAuthor      `json:"author"`
+}
+
+var (
+	parser  = query.MustParser(query.NewParser[exampleArticle]()).WithCursor([]byte("secret"))
+	builder = sqlbuilder.NewBuilder("articles")
+)
+
+func parse(t *testing.T, raw string) query.Query {
+	t.Helper()
+
+	values, _ := url.ParseQuery(raw)
+	q, err := parser.Parse(values)
+
+	assert.NoError(t, err, "should not return an error")
+
+	return q
+}
+
+func TestSelect(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		q := parse(t, "select=id,title&id=gt:1&status=in:draft|review&sort=title:desc,rating:asc_nulls_first&limit=20&offset=40")
+		statement, err := builder.Select(q)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT "id", "headline" FROM "articles" WHERE "id" > $1 AND "status" IN ($2, $3) ORDER BY "headline" DESC, "rating" ASC NULLS FIRST LIMIT $4 OFFSET $5`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{1, "draft", "review", 20, 40}, statement.Args, "args should be equal")
+	})
+
+	t.Run("missing-relation", func(t *testing.T) {
+		for _, raw := range []string{"select=id,author.name", "author.name=Ann", "sort=author.name"} {
+			_, err := builder.Select(parse(t, raw))
+
+			assert.ErrorIs(t, err, sqlbuilder.ErrMissingRelation, "should reject nested columns without a relation")
+		}
+
+		_, err := builder.Count(parse(t, "author.name=Ann"))
+
+		assert.ErrorIs(t, err, sqlbuilder.ErrMissingRelation, "should reject nested columns without a relation")
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		_, err := builder.Select(parse(t, "select=id,author"))
+
+		assert.ErrorIs(t, err, sqlbuilder.ErrCompositeColumn, "should reject struct fields")
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		statement, err := builder.Select(parse(t, ""))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "articles" LIMIT $1`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{query.DefaultBaseLimit}, statement.Args, "args should be equal")
+	})
+
+	t.Run("expression", func(t *testing.T) {
+		q := parse(t, "filter="+url.QueryEscape("(status eq 'draft' or rating gte 3) and not published_at isnull"))
+		statement, err := builder.Select(q)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "articles" WHERE (("status" = $1 OR "rating" >= $2) AND NOT ("published_at" IS NULL)) LIMIT $3`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{"draft", 3, query.DefaultBaseLimit}, statement.Args, "args should be equal")
+	})
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   query.Filtering
+		expected string
+		args     []any
+	}{
+		{name: "eq", filter: query.Filtering{Field: "id", Filter: query.FilterEquals, Value: 1}, expected: `"id" = $1`, args: []any{1}},
+		{name: "neq", filter: query.Filtering{Field: "id", Filter: query.FilterNotEquals, Value: 1}, expected: `"id" <> $1`, args: []any{1}},
+		{name: "lt", filter: query.Filtering{Field: "id", Filter: query.FilterLessThan, Value: 1}, expected: `"id" < $1`, args: []any{1}},
+		{name: "lte", filter: query.Filtering{Field: "id", Filter: query.FilterLessThanEquals, Value: 1}, expected: `"id" <= $1`, args: []any{1}},
+		{name: "gte", filter: query.Filtering{Field: "id", Filter: query.FilterGreateThanEquals, Value: 1}, expected: `"id" >= $1`, args: []any{1}},
+		{name: "isnull", filter: query.Filtering{Field: "rating", Filter: query.FilterIsNull}, expected: `"rating" IS NULL`},
+		{name: "notnull", filter: query.Filtering{Field: "rating", Filter: query.FilterNotNull}, expected: `"rating" IS NOT NULL`},
+		{name: "like", filter: query.Filtering{Field: "title", Filter: query.FilterLike, Value: "a%"}, expected: `"title" LIKE $1 ESCAPE '\'`, args: []any{"a%"}},
+		{name: "ilike", filter: query.Filtering{Field: "title", Filter: query.FilterILike, Value: "a%"}, expected: `"title" ILIKE $1 ESCAPE '\'`, args: []any{"a%"}},
+		{name: "notlike", filter: query.Filtering{Field: "title", Filter: query.FilterNotLike, Value: "a%"}, expected: `"title" NOT LIKE $1 ESCAPE '\'`, args: []any{"a%"}},
+		{name: "contains", filter: query.Filtering{Field: "title", Filter: query.FilterContains, Value: "50%"}, expected: `"title" LIKE $1 ESCAPE '\'`, args: []any{`%50\%%`}},
+		{name: "icontains", filter: query.Filtering{Field: "title", Filter: query.FilterIContains, Value: "go"}, expected: `"title" ILIKE $1 ESCAPE '\'`, args: []any{"%go%"}},
+		{name: "startswith", filter: query.Filtering{Field: "title", Filter: query.FilterStartsWith, Value: "go"}, expected: `"title" LIKE $1 ESCAPE '\'`, args: []any{"go%"}},
+		{name: "endswith", filter: query.Filtering{Field: "title", Filter: query.FilterEndsWith, Value: "go"}, expected: `"title" LIKE $1 ESCAPE '\'`, args: []any{"%go"}},
+		{name: "nin", filter: query.Filtering{Field: "id", Filter: query.FilterNotIn, Value: []int{1, 2}}, expected: `"id" NOT IN ($1, $2)`, args: []any{1, 2}},
+		{name: "in-empty", filter: query.Filtering{Field: "id", Filter: query.FilterIn, Value: []int{}}, expected: `1 = 0`},
+		{name: "between", filter: query.Filtering{Field: "id", Filter: query.FilterBetween, Value: query.Range{Lower: 1, Upper: 5}}, expected: `"id" BETWEEN $1 AND $2`, args: []any{1, 5}},
+		{name: "between-exclusive", filter: query.Filtering{Field: "id", Filter: query.FilterBetweenExclusive, Value: query.Range{Lower: 1, Upper: 5, Exclusive: true}}, expected: `("id" > $1 AND "id" < $2)`, args: []any{1, 5}},
+		{name: "quoted", filter: query.Filtering{Field: "x", Column: `we"ird`, Filter: query.FilterEquals, Value: 1}, expected: `"we""ird" = $1`, args: []any{1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fragments, err := builder.Build(query.Query{Filterings: []query.Filtering{test.filter}})
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, "WHERE "+test.expected, fragments.Where, "where should be equal")
+			assert.Equal(t, test.args, fragments.Args, "args should be equal")
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := builder.Build(query.Query{Filterings: []query.Filtering{{Field: "id", Filter: "regex", Value: "x"}}})
+
+		assert.ErrorIs(t, err, sqlbuilder.ErrUnsupportedFilter, "should reject unknown filters")
+	})
+}
+
+func TestCount(t *testing.T) {
+	q := parse(t, "status=draft&sort=id&limit=5&offset=10&count=only")
+	statement, err := builder.Count(q)
+
+	assert.NoError(t, err, "should not return an error")
+	assert.Equal(t, `SELECT COUNT(*) FROM "articles" WHERE "status" = $1`, statement.SQL, "sql should be equal")
+	assert.Equal(t, []any{"draft"}, statement.Args, "args should be equal")
+}
+
+func TestKeyset(t *testing.T) {
+	article := exampleArticle{Id: 7, Title: "Go", Rating: query.NewNull(0, false)}
+
+	t.Run("next", func(t *testing.T) {
+		q := parse(t, "sort=title:desc,id&status=draft&limit=10&offset=30")
+		cursor, err := parser.NextCursor(q, article)
+
+		assert.NoError(t, err, "should not return an error")
+
+		statement, err := builder.Select(parse(t, "sort=title:desc,id&status=draft&limit=10&cursor="+cursor))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "articles" WHERE "status" = $1 AND ("headline" < $2 OR ("headline" = $3 AND "id" > $4)) ORDER BY "headline" DESC, "id" ASC LIMIT $5`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{"draft", "Go", "Go", 7, 10}, statement.Args, "args should be equal")
+	})
+
+	t.Run("nullable-default-order", func(t *testing.T) {
+		rated := exampleArticle{Id: 7, Rating: query.NewNull(3, true)}
+		q := parse(t, "sort=rating,id")
+		cursor, err := parser.NextCursor(q, rated)
+
+		assert.NoError(t, err, "should not return an error")
+
+		statement, err := builder.Select(parse(t, "sort=rating,id&cursor="+cursor))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "articles" WHERE (("rating" > $1 OR "rating" IS NULL) OR ("rating" = $2 AND "id" > $3)) ORDER BY "rating" ASC, "id" ASC LIMIT $4`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{3, 3, 7, query.DefaultBaseLimit}, statement.Args, "args should be equal")
+	})
+
+	t.Run("prev-nulls", func(t *testing.T) {
+		q := parse(t, "sort=rating:asc_nulls_last,id")
+		cursor, err := parser.PrevCursor(q, article)
+
+		assert.NoError(t, err, "should not return an error")
+
+		statement, err := builder.Select(parse(t, "sort=rating:asc_nulls_last,id&cursor="+cursor))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "articles" WHERE ("rating" IS NOT NULL OR ("rating" IS NULL AND "id" < $1)) ORDER BY "rating" DESC NULLS FIRST, "id" DESC LIMIT $2`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{7, query.DefaultBaseLimit}, statement.Args, "args should be equal")
+	})
+}
@@ -0,0 +1,290 @@
+package sqlbuilder
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/securehaven/query"
+)
+
+type Nulls int
+
+const (
+	NullsDefault Nulls = iota
+	NullsFirst
+	NullsLast
+)
+
+type Dialect interface {
+	Placeholder(n int) string
+	QuoteIdentifier(identifier string) string
+	EscapeLike(v string) string
+	LikeValue(pattern string, insensitive bool) string
+	Like(column string, pattern string, insensitive bool, negated bool) string
+	Order(column string, descending bool, nulls Nulls) string
+	NullsSortHigh() bool
+	Limit(limit string, offset string, ordered bool) string
+}
+
+type Postgres struct{}
+
+type MySQL struct{}
+
+type SQLite struct{}
+
+type SQLServer struct{}
+
+func (Postgres) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (Postgres) QuoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func (Postgres) EscapeLike(v string) string {
+	return query.EscapeLike(v)
+}
+
+func (Postgres) LikeValue(pattern string, insensitive bool) string {
+	return pattern
+}
+
+func (Postgres) Like(column string, pattern string, insensitive bool, negated bool) string {
+	operator := "LIKE"
+
+	if insensitive {
+		operator = "ILIKE"
+	}
+
+	return column + not(negated) + " " + operator + " " + pattern + " ESCAPE " + quoteString(query.LikeEscape)
+}
+
+func (Postgres) Order(column string, descending bool, nulls Nulls) string {
+	return column + " " + direction(descending) + nullsSQL[nulls]
+}
+
+func (Postgres) NullsSortHigh() bool {
+	return true
+}
+
+func (Postgres) Limit(limit string, offset string, ordered bool) string {
+	return limitOffset(limit, offset, "")
+}
+
+func (MySQL) Placeholder(n int) string {
+	return "?"
+}
+
+func (MySQL) QuoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+func (MySQL) EscapeLike(v string) string {
+	return query.EscapeLike(v)
+}
+
+func (MySQL) LikeValue(pattern string, insensitive bool) string {
+	return pattern
+}
+
+func (MySQL) Like(column string, pattern string, insensitive bool, negated bool) string {
+	escape := "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(query.LikeEscape) + "'"
+
+	if !insensitive {
+		return column + not(negated) + " LIKE CAST(" + pattern + " AS BINARY) ESCAPE " + escape
+	}
+
+	return lowerLike(column, pattern, insensitive, negated) + " ESCAPE " + escape
+}
+
+func (MySQL) Order(column string, descending bool, nulls Nulls) string {
+	return emulateNulls(column, descending, nulls)
+}
+
+func (MySQL) NullsSortHigh() bool {
+	return false
+}
+
+func (MySQL) Limit(limit string, offset string, ordered bool) string {
+	return limitOffset(limit, offset, "18446744073709551615")
+}
+
+func (SQLite) Placeholder(n int) string {
+	return "?"
+}
+
+func (SQLite) QuoteIdentifier(identifier string) string {
+	return Postgres{}.QuoteIdentifier(identifier)
+}
+
+func (SQLite) EscapeLike(v string) string {
+	return query.EscapeLike(v)
+}
+
+func (SQLite) LikeValue(pattern string, insensitive bool) string {
+	if insensitive {
+		return pattern
+	}
+
+	return likeToGlob(pattern)
+}
+
+func (SQLite) Like(column string, pattern string, insensitive bool, negated bool) string {
+	if !insensitive {
+		return column + not(negated) + " GLOB " + pattern
+	}
+
+	return lowerLike(column, pattern, insensitive, negated) + " ESCAPE " + quoteString(query.LikeEscape)
+}
+
+func (SQLite) Order(column string, descending bool, nulls Nulls) string {
+	return column + " " + direction(descending) + nullsSQL[nulls]
+}
+
+func (SQLite) NullsSortHigh() bool {
+	return false
+}
+
+func (SQLite) Limit(limit string, offset string, ordered bool) string {
+	return limitOffset(limit, offset, "-1")
+}
+
+func (SQLServer) Placeholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+func (SQLServer) QuoteIdentifier(identifier string) string {
+	return "[" + strings.ReplaceAll(identifier, "]", "]]") + "]"
+}
+
+func (SQLServer) EscapeLike(v string) string {
+	return strings.ReplaceAll(query.EscapeLike(v), "[", query.LikeEscape+"[")
+}
+
+func (SQLServer) LikeValue(pattern string, insensitive bool) string {
+	return pattern
+}
+
+func (SQLServer) Like(column string, pattern string, insensitive bool, negated bool) string {
+	return lowerLike(column, pattern, insensitive, negated) + " ESCAPE " + quoteString(query.LikeEscape)
+}
+
+func (SQLServer) Order(column string, descending bool, nulls Nulls) string {
+	return emulateNulls(column, descending, nulls)
+}
+
+func (SQLServer) NullsSortHigh() bool {
+	return false
+}
+
+func (SQLServer) Limit(limit string, offset string, ordered bool) string {
+	if len(offset) == 0 {
+		offset = "0"
+	}
+
+	clause := "OFFSET " + offset + " ROWS"
+
+	if len(limit) > 0 {
+		clause += " FETCH NEXT " + limit + " ROWS ONLY"
+	}
+
+	if !ordered {
+		clause = "ORDER BY (SELECT NULL) " + clause
+	}
+
+	return clause
+}
+
+var nullsSQL = map[Nulls]string{
+	NullsDefault: "",
+	NullsFirst:   " NULLS FIRST",
+	NullsLast:    " NULLS LAST",
+}
+
+func direction(descending bool) string {
+	if descending {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+func not(negated bool) string {
+	if negated {
+		return " NOT"
+	}
+
+	return ""
+}
+
+func lowerLike(column string, pattern string, insensitive bool, negated bool) string {
+	if insensitive {
+		column, pattern = "LOWER("+column+")", "LOWER("+pattern+")"
+	}
+
+	return column + not(negated) + " LIKE " + pattern
+}
+
+func emulateNulls(column string, descending bool, nulls Nulls) string {
+	order := column + " " + direction(descending)
+
+	switch nulls {
+	case NullsFirst:
+		return "CASE WHEN " + column + " IS NULL THEN 0 ELSE 1 END, " + order
+	case NullsLast:
+		return "CASE WHEN " + column + " IS NULL THEN 1 ELSE 0 END, " + order
+	}
+
+	return order
+}
+
+func likeToGlob(pattern string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i : i+1]
+
+		switch {
+		case strings.HasPrefix(pattern[i:], query.LikeEscape) && i+len(query.LikeEscape) < len(pattern):
+			i += len(query.LikeEscape)
+			b.WriteString(globLiteral(pattern[i : i+1]))
+		case c == "%":
+			b.WriteString("*")
+		case c == "_":
+			b.WriteString("?")
+		default:
+			b.WriteString(globLiteral(c))
+		}
+	}
+
+	return b.String()
+}
+
+func globLiteral(c string) string {
+	if strings.ContainsAny(c, "*?[") {
+		return "[" + c + "]"
+	}
+
+	return c
+}
+
+func limitOffset(limit string, offset string, unlimited string) string {
+	if len(limit) == 0 {
+		if len(unlimited) == 0 {
+			return "OFFSET " + offset
+		}
+
+		limit = unlimited
+	}
+
+	if len(offset) == 0 {
+		return "LIMIT " + limit
+	}
+
+	return "LIMIT " + limit + " OFFSET " + offset
+}
+
+func quoteString(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
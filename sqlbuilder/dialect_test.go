@@ -0,0 +1,264 @@
+package sqlbuilder_test
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/securehaven/query/sqlbuilder"
+	"github.com/stretchr/testify/assert"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestDialects(t *testing.T) {
+	q := parse(t, "select=id,title&title=icontains:50%25&rating=isnull&sort=rating:asc_nulls_last,id:desc&limit=10&offset=20")
+
+	tests := []struct {
+		name     string
+		dialect  sqlbuilder.Dialect
+		expected string
+	}{
+		{
+			name:     "postgres",
+			dialect:  sqlbuilder.Postgres{},
+			expected: `SELECT "id", "headline" FROM "articles" WHERE "headline" ILIKE $1 ESCAPE '\' AND "rating" IS NULL ORDER BY "rating" ASC NULLS LAST, "id" DESC LIMIT $2 OFFSET $3`,
+		},
+		{
+			name:     "mysql",
+			dialect:  sqlbuilder.MySQL{},
+			expected: "SELECT `id`, `headline` FROM `articles` WHERE LOWER(`headline`) LIKE LOWER(?) ESCAPE '\\\\' AND `rating` IS NULL ORDER BY CASE WHEN `rating` IS NULL THEN 1 ELSE 0 END, `rating` ASC, `id` DESC LIMIT ? OFFSET ?",
+		},
+		{
+			name:     "sqlite",
+			dialect:  sqlbuilder.SQLite{},
+			expected: `SELECT "id", "headline" FROM "articles" WHERE LOWER("headline") LIKE LOWER(?) ESCAPE '\' AND "rating" IS NULL ORDER BY "rating" ASC NULLS LAST, "id" DESC LIMIT ? OFFSET ?`,
+		},
+		{
+			name:     "sqlserver",
+			dialect:  sqlbuilder.SQLServer{},
+			expected: `SELECT [id], [headline] FROM [articles] WHERE LOWER([headline]) LIKE LOWER(@p1) ESCAPE '\' AND [rating] IS NULL ORDER BY CASE WHEN [rating] IS NULL THEN 1 ELSE 0 END, [rating] ASC, [id] DESC OFFSET @p3 ROWS FETCH NEXT @p2 ROWS ONLY`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statement, err := sqlbuilder.NewBuilder("articles").WithDialect(test.dialect).Select(q)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, test.expected, statement.SQL, "sql should be equal")
+			assert.Equal(t, []any{`%50\%%`, 10, 20}, statement.Args, "args should be equal")
+		})
+	}
+
+	t.Run("case-sensitive", func(t *testing.T) {
+		q := parse(t, "title=contains:a*b_")
+
+		for dialect, expected := range map[sqlbuilder.Dialect]struct {
+			sql string
+			arg string
+		}{
+			sqlbuilder.Postgres{}:  {sql: `WHERE "headline" LIKE $1 ESCAPE '\'`, arg: `%a*b\_%`},
+			sqlbuilder.MySQL{}:     {sql: "WHERE `headline` LIKE CAST(? AS BINARY) ESCAPE '\\\\'", arg: `%a*b\_%`},
+			sqlbuilder.SQLite{}:    {sql: `WHERE "headline" GLOB ?`, arg: `*a[*]b_*`},
+			sqlbuilder.SQLServer{}: {sql: `WHERE [headline] LIKE @p1 ESCAPE '\'`, arg: `%a*b\_%`},
+		} {
+			statement, err := sqlbuilder.NewBuilder("articles").WithDialect(dialect).Build(q)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, expected.sql, statement.Where, "sql should be equal")
+			assert.Equal(t, expected.arg, statement.Args[0], "arg should be equal")
+		}
+	})
+
+	t.Run("offset-without-limit", func(t *testing.T) {
+		unlimitedParser := query.MustParser(query.NewParser[exampleArticle]()).WithBaseLimit(0)
+		q, err := unlimitedParser.Parse(url.Values{query.ParamOffset: {"20"}})
+
+		assert.NoError(t, err, "should not return an error")
+
+		for dialect, expected := range map[sqlbuilder.Dialect]string{
+			sqlbuilder.Postgres{}:  `SELECT * FROM "articles" OFFSET $1`,
+			sqlbuilder.MySQL{}:     "SELECT * FROM `articles` LIMIT 18446744073709551615 OFFSET ?",
+			sqlbuilder.SQLite{}:    `SELECT * FROM "articles" LIMIT -1 OFFSET ?`,
+			sqlbuilder.SQLServer{}: `SELECT * FROM [articles] ORDER BY (SELECT NULL) OFFSET @p1 ROWS`,
+		} {
+			statement, err := sqlbuilder.NewBuilder("articles").WithDialect(dialect).Select(q)
+
+			assert.NoError(t, err, "should not return an error")
+			assert.Equal(t, expected, statement.SQL, "sql should be equal")
+			assert.Equal(t, []any{20}, statement.Args, "args should be equal")
+		}
+	})
+
+	t.Run("sqlserver-unordered", func(t *testing.T) {
+		statement, err := sqlbuilder.NewBuilder("articles").WithDialect(sqlbuilder.SQLServer{}).Select(parse(t, "title=startswith:[a]"))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM [articles] WHERE [headline] LIKE @p1 ESCAPE '\' ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT @p2 ROWS ONLY`, statement.SQL, "sql should be equal")
+		assert.Equal(t, []any{`\[a]%`, query.DefaultBaseLimit}, statement.Args, "args should be equal")
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+
+	if !assert.NoError(t, err, "should open the database") {
+		return
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE articles (id INTEGER PRIMARY KEY, headline TEXT, status TEXT, rating INTEGER, published_at TEXT);
+		INSERT INTO articles VALUES
+			(1, 'Intro to Go', 'draft', 3, NULL),
+			(2, 'Go 100% faster', 'published', NULL, '2024-01-01'),
+			(3, 'Rust vs Go', 'published', 5, '2024-02-01'),
+			(4, 'Under_score', 'review', 3, NULL),
+			(5, 'gopher tales', 'published', NULL, '2024-03-01'),
+			(6, 'Databases', 'draft', 1, NULL);
+	`)
+
+	if !assert.NoError(t, err, "should create the table") {
+		return
+	}
+
+	sqliteBuilder := sqlbuilder.NewBuilder("articles").WithDialect(sqlbuilder.SQLite{})
+
+	ids := func(t *testing.T, q query.Query) []int {
+		t.Helper()
+
+		statement, err := sqliteBuilder.Select(q)
+		assert.NoError(t, err, "should build the statement")
+
+		rows, err := db.Query(statement.SQL, statement.Args...)
+
+		if !assert.NoError(t, err, "should run the statement") {
+			return nil
+		}
+
+		defer rows.Close()
+
+		columns, _ := rows.Columns()
+		ids := make([]int, 0)
+
+		for rows.Next() {
+			values := make([]any, len(columns))
+			values[0] = new(int)
+
+			for i := 1; i < len(values); i++ {
+				values[i] = new(any)
+			}
+
+			assert.NoError(t, rows.Scan(values...), "should scan the row")
+
+			ids = append(ids, *values[0].(*int))
+		}
+
+		return ids
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []int
+	}{
+		{name: "filters", query: "status=in:draft|review&rating=gte:2&sort=id", expected: []int{1, 4}},
+		{name: "contains-escaped", query: "title=contains:100%25&sort=id", expected: []int{2}},
+		{name: "underscore-escaped", query: "title=contains:_&sort=id", expected: []int{4}},
+		{name: "icontains", query: "title=icontains:GO&sort=id", expected: []int{1, 2, 3, 5}},
+		{name: "startswith-case", query: "title=startswith:go&sort=id", expected: []int{5}},
+		{name: "like-case", query: "title=like:%25Go%25&sort=id", expected: []int{1, 2, 3}},
+		{name: "notlike-case", query: "title=notlike:%25Go%25&sort=id", expected: []int{4, 5, 6}},
+		{name: "null", query: "rating=isnull&sort=id:desc", expected: []int{5, 2}},
+		{name: "nulls-first", query: "sort=rating:asc_nulls_first,id&select=id", expected: []int{2, 5, 6, 1, 4, 3}},
+		{name: "nulls-last", query: "sort=rating:desc_nulls_last,id&select=id", expected: []int{3, 1, 4, 6, 2, 5}},
+		{name: "pagination", query: "sort=id&limit=2&offset=2", expected: []int{3, 4}},
+		{name: "between", query: "rating=between:1|3&sort=id", expected: []int{1, 4, 6}},
+		{name: "expression", query: "filter=" + url.QueryEscape("(status eq 'draft' or rating eq 5) and not id eq 6") + "&sort=id", expected: []int{1, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ids(t, parse(t, test.query)), "ids should be equal")
+		})
+	}
+
+	t.Run("offset-without-limit", func(t *testing.T) {
+		assert.Equal(t, []int{5, 6}, ids(t, query.Query{Offset: 4, Sortings: parse(t, "sort=id").Sortings}), "ids should be equal")
+	})
+
+	t.Run("count", func(t *testing.T) {
+		statement, err := sqliteBuilder.Count(parse(t, "status=published&limit=1"))
+		assert.NoError(t, err, "should build the statement")
+
+		var count int
+
+		assert.NoError(t, db.QueryRow(statement.SQL, statement.Args...).Scan(&count), "should count")
+		assert.Equal(t, 3, count, "count should be equal")
+	})
+
+	t.Run("keyset", func(t *testing.T) {
+		for _, sort := range []string{"rating:asc_nulls_first,id", "rating:desc_nulls_last,id:desc", "rating,id", "rating:desc,id"} {
+			t.Run(sort, func(t *testing.T) {
+				raw := "limit=2&sort=" + sort
+				expected := ids(t, parse(t, "limit=10&sort="+sort))
+				pages := make([]int, 0, len(expected))
+				q := parse(t, raw)
+
+				for range len(expected) {
+					page := ids(t, q)
+
+					if len(page) == 0 {
+						break
+					}
+
+					pages = append(pages, page...)
+
+					cursor, err := parser.NextCursor(q, exampleArticleWithId(t, db, page[len(page)-1]))
+					assert.NoError(t, err, "should mint a cursor")
+
+					q = parse(t, raw+"&cursor="+cursor)
+				}
+
+				assert.Equal(t, expected, pages, "keyset pages should cover all rows in order")
+
+				last := parse(t, raw+"&cursor="+mustCursor(t, q, exampleArticleWithId(t, db, expected[len(expected)-1]), false))
+				prev := ids(t, last)
+
+				assert.Equal(t, []int{expected[len(expected)-2], expected[len(expected)-3]}, prev, "previous page should be reversed")
+			})
+		}
+	})
+}
+
+func exampleArticleWithId(t *testing.T, db *sql.DB, id int) exampleArticle {
+	t.Helper()
+
+	var article exampleArticle
+	var rating sql.NullInt64
+
+	err := db.QueryRow(`SELECT id, headline, status, rating FROM articles WHERE id = ?`, id).Scan(&article.Id, &article.Title, &article.Status, &rating)
+	assert.NoError(t, err, "should load the article")
+
+	article.Rating = query.NewNull(int(rating.Int64), rating.Valid)
+
+	return article
+}
+
+func mustCursor(t *testing.T, q query.Query, article exampleArticle, next bool) string {
+	t.Helper()
+
+	mint := parser.PrevCursor
+
+	if next {
+		mint = parser.NextCursor
+	}
+
+	cursor, err := mint(q, article)
+	assert.NoError(t, err, "should mint a cursor")
+
+	return cursor
+}
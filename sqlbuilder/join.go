@@ -0,0 +1,142 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/securehaven/query"
+)
+
+func (b *Builder) writer(q query.Query) (*writer, error) {
+	w := &writer{
+		dialect:   b.dialect,
+		table:     b.table,
+		relations: b.relations,
+		joined:    make(map[string]bool),
+	}
+
+	for _, selection := range q.Selections {
+		if selection.Composite {
+			return nil, fmt.Errorf("%w: %q", ErrCompositeColumn, selection.Field)
+		}
+	}
+
+	for _, column := range referencedColumns(q) {
+		relation, ok := w.relation(column)
+
+		if !ok {
+			if strings.Contains(column, query.SeparatorSelector) {
+				return nil, fmt.Errorf("%w: %q", ErrMissingRelation, column)
+			}
+
+			continue
+		}
+
+		w.join(relation.Path)
+	}
+
+	return w, nil
+}
+
+func (w *writer) join(path string) {
+	for len(path) > 0 {
+		w.joined[path] = true
+
+		parent, ok := w.relation(path)
+
+		if !ok {
+			return
+		}
+
+		path = parent.Path
+	}
+}
+
+func (w *writer) joins() string {
+	joins := make([]string, 0, len(w.joined))
+
+	for _, relation := range w.relations {
+		if !w.joined[relation.Path] {
+			continue
+		}
+
+		alias := w.dialect.QuoteIdentifier(relationAlias(relation.Path))
+		parent := quoteColumn(w.dialect, w.table)
+
+		if parentRelation, ok := w.relation(relation.Path); ok {
+			parent = w.dialect.QuoteIdentifier(relationAlias(parentRelation.Path))
+		}
+
+		joins = append(joins, "LEFT JOIN "+quoteColumn(w.dialect, relation.Table)+" AS "+alias+
+			" ON "+alias+"."+w.dialect.QuoteIdentifier(relation.ForeignKey)+" = "+parent+"."+w.dialect.QuoteIdentifier(relation.LocalKey))
+	}
+
+	return strings.Join(joins, " ")
+}
+
+func (w *writer) column(column string, field string) string {
+	column = columnName(column, field)
+
+	if relation, ok := w.relation(column); ok {
+		return w.dialect.QuoteIdentifier(relationAlias(relation.Path)) + "." + quoteColumn(w.dialect, column[len(relation.Path)+1:])
+	}
+
+	if len(w.joined) > 0 && !strings.Contains(column, query.SeparatorSelector) {
+		return quoteColumn(w.dialect, w.table) + "." + w.dialect.QuoteIdentifier(column)
+	}
+
+	return quoteColumn(w.dialect, column)
+}
+
+func (w *writer) relation(column string) (query.Relation, bool) {
+	match := query.Relation{}
+
+	for _, relation := range w.relations {
+		if strings.HasPrefix(column, relation.Path+query.SeparatorSelector) && len(relation.Path) > len(match.Path) {
+			match = relation
+		}
+	}
+
+	return match, len(match.Path) > 0
+}
+
+func relationAlias(path string) string {
+	return strings.ReplaceAll(path, query.SeparatorSelector, "__")
+}
+
+func referencedColumns(q query.Query) []string {
+	columns := make([]string, 0, len(q.Selections)+len(q.Sortings)+len(q.Filterings))
+
+	for _, selection := range q.Selections {
+		columns = append(columns, columnName(selection.Column, selection.Field))
+	}
+
+	for _, sorting := range q.Sortings {
+		columns = append(columns, columnName(sorting.Column, sorting.Field))
+	}
+
+	for _, filtering := range q.Filterings {
+		columns = append(columns, columnName(filtering.Column, filtering.Field))
+	}
+
+	return appendExprColumns(columns, q.Where)
+}
+
+func appendExprColumns(columns []string, expr query.Expr) []string {
+	switch e := expr.(type) {
+	case query.Filtering:
+		return append(columns, columnName(e.Column, e.Field))
+	case query.AndExpr:
+		for _, expr := range e.Exprs {
+			columns = appendExprColumns(columns, expr)
+		}
+	case query.OrExpr:
+		for _, expr := range e.Exprs {
+			columns = appendExprColumns(columns, expr)
+		}
+	case query.NotExpr:
+		return appendExprColumns(columns, e.Expr)
+	}
+
+	return columns
+}
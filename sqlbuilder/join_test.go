@@ -0,0 +1,140 @@
+package sqlbuilder_test
+
+import (
+	"database/sql"
+	"net/url"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/securehaven/query/sqlbuilder"
+	"github.com/stretchr/testify/assert"
+)
+
+type exampleCompany struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type exampleWriter struct {
+	Id      int            `json:"id"`
+	Name    string         `json:"name"`
+	Company exampleCompany `json:"company" query:"join=companies"`
+}
+
+type exampleStory struct {
+	Id     int            `json:"id"`
+	Title  string         `json:"title"`
+	Writer exampleWriter  `json:"writer" query:"join=users;local_key=writer_id"`
+	Editor *exampleWriter `json:"editor" query:"join=users"`
+}
+
+var storyParser = query.MustParser(query.NewParser[exampleStory]())
+
+func TestRelations(t *testing.T) {
+	assert.Equal(t, []query.Relation{
+		{Path: "writer", Table: "users", LocalKey: "writer_id", ForeignKey: "id"},
+		{Path: "writer.company", Table: "companies", LocalKey: "company_id", ForeignKey: "id"},
+		{Path: "editor", Table: "users", LocalKey: "editor_id", ForeignKey: "id"},
+		{Path: "editor.company", Table: "companies", LocalKey: "company_id", ForeignKey: "id"},
+	}, storyParser.Relations(), "relations should be equal")
+}
+
+func TestJoins(t *testing.T) {
+	storyBuilder := sqlbuilder.NewBuilder("stories").WithRelations(storyParser.Relations())
+
+	parseStory := func(t *testing.T, raw string) query.Query {
+		t.Helper()
+
+		values, _ := url.ParseQuery(raw)
+		q, err := storyParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+
+		return q
+	}
+
+	t.Run("referenced-only", func(t *testing.T) {
+		statement, err := storyBuilder.Select(parseStory(t, "select=id,title&writer.company.name=Acme&sort=editor.name"))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT "stories"."id", "stories"."title" FROM "stories" `+
+			`LEFT JOIN "users" AS "writer" ON "writer"."id" = "stories"."writer_id" `+
+			`LEFT JOIN "companies" AS "writer__company" ON "writer__company"."id" = "writer"."company_id" `+
+			`LEFT JOIN "users" AS "editor" ON "editor"."id" = "stories"."editor_id" `+
+			`WHERE "writer__company"."name" = $1 ORDER BY "editor"."name" ASC LIMIT $2`, statement.SQL, "sql should be equal")
+	})
+
+	t.Run("no-joins", func(t *testing.T) {
+		statement, err := storyBuilder.Select(parseStory(t, "title=Go"))
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT * FROM "stories" WHERE "title" = $1 LIMIT $2`, statement.SQL, "sql should be equal")
+	})
+
+	t.Run("expression-and-count", func(t *testing.T) {
+		q := parseStory(t, "filter=editor.name eq 'Ann' or id eq 1&select=writer.name&sort=title")
+		statement, err := storyBuilder.Count(q)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `SELECT COUNT(*) FROM "stories" LEFT JOIN "users" AS "editor" ON "editor"."id" = "stories"."editor_id" WHERE ("editor"."name" = $1 OR "stories"."id" = $2)`, statement.SQL, "sql should be equal")
+
+		fragments, err := storyBuilder.Build(q)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, `"writer"."name"`, fragments.Columns, "columns should be equal")
+		assert.Equal(t, `LEFT JOIN "users" AS "writer" ON "writer"."id" = "stories"."writer_id" LEFT JOIN "users" AS "editor" ON "editor"."id" = "stories"."editor_id"`, fragments.Joins, "joins should be equal")
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		db, err := sql.Open("sqlite", ":memory:")
+
+		if !assert.NoError(t, err, "should open the database") {
+			return
+		}
+
+		defer db.Close()
+
+		_, err = db.Exec(`
+			CREATE TABLE companies (id INTEGER PRIMARY KEY, name TEXT);
+			CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, company_id INTEGER);
+			CREATE TABLE stories (id INTEGER PRIMARY KEY, title TEXT, writer_id INTEGER, editor_id INTEGER);
+			INSERT INTO companies VALUES (1, 'Acme'), (2, 'Globex');
+			INSERT INTO users VALUES (1, 'Ann', 1), (2, 'Bob', 2), (3, 'Cid', 1);
+			INSERT INTO stories VALUES (1, 'One', 1, 2), (2, 'Two', 2, NULL), (3, 'Three', 3, 1), (4, 'Four', 2, 3);
+		`)
+
+		if !assert.NoError(t, err, "should create the tables") {
+			return
+		}
+
+		sqliteBuilder := sqlbuilder.NewBuilder("stories").WithDialect(sqlbuilder.SQLite{}).WithRelations(storyParser.Relations())
+		statement, err := sqliteBuilder.Select(parseStory(t, "select=id,writer.name&writer.company.name=Acme&sort=editor.name:desc_nulls_last,id"))
+
+		assert.NoError(t, err, "should not return an error")
+
+		rows, err := db.Query(statement.SQL, statement.Args...)
+
+		if !assert.NoError(t, err, "should run the statement") {
+			return
+		}
+
+		defer rows.Close()
+
+		type row struct {
+			Id     int
+			Writer string
+		}
+
+		result := make([]row, 0)
+
+		for rows.Next() {
+			var r row
+
+			assert.NoError(t, rows.Scan(&r.Id, &r.Writer), "should scan the row")
+
+			result = append(result, r)
+		}
+
+		assert.Equal(t, []row{{Id: 1, Writer: "Ann"}, {Id: 3, Writer: "Cid"}}, result, "rows should be equal")
+	})
+}
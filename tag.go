@@ -0,0 +1,179 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	tagKey = "query"
+
+	tagSkip   = "-"
+	tagName   = "name"
+	tagAlias  = "alias"
+	tagColumn = "column"
+	tagOps    = "ops"
+	tagFilter = "filter"
+	tagSort   = "sort"
+	tagSelect = "select"
+
+	tagJoin       = "join"
+	tagLocalKey   = "local_key"
+	tagForeignKey = "foreign_key"
+
+	separatorTagOption = ";"
+	separatorTagValue  = "="
+)
+
+type fieldTag []fieldTagOption
+
+type fieldTagOption struct {
+	key   string
+	value string
+}
+
+func getFieldTagFromStructField(sf reflect.StructField) fieldTag {
+	raw := strings.TrimSpace(sf.Tag.Get(tagKey))
+	tag := make(fieldTag, 0)
+
+	for _, option := range strings.Split(raw, separatorTagOption) {
+		key, value, _ := strings.Cut(option, separatorTagValue)
+		key = strings.TrimSpace(key)
+
+		if len(key) > 0 {
+			tag = append(tag, fieldTagOption{key: key, value: strings.TrimSpace(value)})
+		}
+	}
+
+	return tag
+}
+
+func (t fieldTag) skip() bool {
+	return t.has(tagSkip)
+}
+
+func (t fieldTag) has(key string) bool {
+	return slices.ContainsFunc(t, func(option fieldTagOption) bool {
+		return option.key == key
+	})
+}
+
+func (t fieldTag) apply(f field) (field, error) {
+	var err error
+
+	for _, option := range t {
+		key, value := option.key, option.value
+
+		switch key {
+		case tagName:
+			f.name, err = t.parseName(value)
+		case tagAlias:
+			f.aliases, err = t.parseAliases(value)
+		case tagColumn:
+			f.column, err = t.parseName(value)
+		case tagOps:
+			f.filters, err = t.parseFilters(f, value)
+		case tagFilter:
+			f.filterable, err = strconv.ParseBool(value)
+		case tagSort:
+			f.sortable, err = strconv.ParseBool(value)
+		case tagSelect:
+			f.selectable, err = strconv.ParseBool(value)
+		case tagJoin:
+			f.join.table, err = t.parseJoin(f, value)
+		case tagLocalKey:
+			f.join.localKey, err = t.parseJoinKey(value)
+		case tagForeignKey:
+			f.join.foreignKey, err = t.parseJoinKey(value)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+
+		if err != nil {
+			return f, fmt.Errorf("field %q: %w: %w", f.name, ErrInvalidTag, err)
+		}
+	}
+
+	if !t.has(tagColumn) {
+		f.column = f.name
+	}
+
+	return t.applyJoinDefaults(f)
+}
+
+func (t fieldTag) parseJoin(f field, raw string) (string, error) {
+	if isLeafType(f.typ) {
+		return "", fmt.Errorf("join requires a struct field, got %s", f.typ)
+	}
+
+	return t.parseName(raw)
+}
+
+func (t fieldTag) parseJoinKey(raw string) (string, error) {
+	return t.parseName(raw)
+}
+
+func (t fieldTag) applyJoinDefaults(f field) (field, error) {
+	if len(f.join.table) == 0 {
+		if len(f.join.localKey) > 0 || len(f.join.foreignKey) > 0 {
+			return f, fmt.Errorf("field %q: %w: join keys require a join table", f.name, ErrInvalidTag)
+		}
+
+		return f, nil
+	}
+
+	if len(f.join.localKey) == 0 {
+		f.join.localKey = f.column + "_" + DefaultForeignKey
+	}
+
+	if len(f.join.foreignKey) == 0 {
+		f.join.foreignKey = DefaultForeignKey
+	}
+
+	return f, nil
+}
+
+func (t fieldTag) parseName(raw string) (string, error) {
+	if len(raw) == 0 {
+		return "", fmt.Errorf("empty name")
+	}
+
+	return raw, nil
+}
+
+func (t fieldTag) parseAliases(raw string) ([]string, error) {
+	aliases := make([]string, 0)
+
+	for _, alias := range strings.Split(raw, SeparatorField) {
+		alias = strings.TrimSpace(alias)
+
+		if len(alias) > 0 {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases, nil
+}
+
+func (t fieldTag) parseFilters(f field, raw string) ([]string, error) {
+	filters := make([]string, 0)
+
+	for _, filter := range strings.Split(raw, SeparatorField) {
+		filter = strings.TrimSpace(filter)
+
+		if len(filter) == 0 {
+			continue
+		}
+
+		if !f.allowsFilter(filter) {
+			return nil, fmt.Errorf("filter operator %q is not supported by %s", filter, f.typ)
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return slices.Compact(filters), nil
+}
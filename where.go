@@ -0,0 +1,359 @@
+package query
+
+import (
+	"strings"
+)
+
+const maxExprDepth = 32
+
+var (
+	whereSymbols    = []string{"(", ")", ","}
+	whereDelimiters = "(),"
+)
+
+type whereParser struct {
+	parser        *Parser
+	lexer         *lexer
+	depth         int
+	param         string
+	filters       map[string]string
+	functions     map[string]string
+	pathSeparator string
+}
+
+type FilterSyntax string
+
+const (
+	FilterSyntaxExpression FilterSyntax = "expression"
+	FilterSyntaxRSQL       FilterSyntax = "rsql"
+	FilterSyntaxAIP160     FilterSyntax = "aip160"
+)
+
+func (p *Parser) ParseFilter(raw string) (Expr, error) {
+	parsingError := ParsingError{}
+
+	expr, err := p.parseWhere(raw)
+	parsingError.add(err)
+
+	return expr, parsingError.err()
+}
+
+func (p *Parser) parseWhere(raw string) (Expr, error) {
+	if p.odata {
+		return p.parseODataFilter(raw)
+	}
+
+	switch p.filterSyntax {
+	case FilterSyntaxRSQL:
+		return p.parseRSQL(raw)
+	case FilterSyntaxAIP160:
+		return p.parseAIP160(raw)
+	default:
+		return p.parseExpression(raw)
+	}
+}
+
+func (p *Parser) parseExpression(raw string) (Expr, error) {
+	if len(strings.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	w := whereParser{
+		parser: p,
+		lexer:  newLexer(raw, whereSymbols, whereDelimiters, "'", false),
+		param:  ParamFilter,
+	}
+
+	expr, err := w.parse()
+
+	return expr, wrapSyntaxError(ParamFilter, raw, err)
+}
+
+func (w *whereParser) parse() (Expr, error) {
+	expr, err := w.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := w.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if t.kind != tokenEOF {
+		return nil, w.lexer.errorf(t.pos, "unexpected %s", t)
+	}
+
+	return expr, nil
+}
+
+func (w *whereParser) parseOr() (Expr, error) {
+	return w.parseBinary("or", w.parseAnd, newOrExpr)
+}
+
+func (w *whereParser) parseAnd() (Expr, error) {
+	return w.parseBinary("and", w.parseUnary, newAndExpr)
+}
+
+func (w *whereParser) parseBinary(keyword string, operand func() (Expr, error), combine func([]Expr) Expr) (Expr, error) {
+	expr, err := operand()
+
+	if err != nil {
+		return nil, err
+	}
+
+	exprs := []Expr{expr}
+
+	for {
+		t, err := w.lexer.peek()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.isWord(keyword) {
+			break
+		}
+
+		w.lexer.next()
+
+		if expr, err = operand(); err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, expr)
+	}
+
+	return combine(exprs), nil
+}
+
+func (w *whereParser) parseUnary() (Expr, error) {
+	t, err := w.lexer.peek()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.isWord("not") {
+		return w.parsePrimary()
+	}
+
+	if w.depth >= maxExprDepth {
+		return nil, w.lexer.errorf(t.pos, "expression is nested too deeply")
+	}
+
+	w.lexer.next()
+	w.depth++
+
+	expr, err := w.parseUnary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.depth--
+
+	return NotExpr{Expr: expr}, nil
+}
+
+func (w *whereParser) parsePrimary() (Expr, error) {
+	t, err := w.lexer.peek()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.is(tokenSymbol, "(") {
+		if _, ok := w.functions[strings.ToLower(t.text)]; ok && t.kind == tokenWord {
+			return w.parseFunction()
+		}
+
+		return w.parseComparison()
+	}
+
+	if w.depth >= maxExprDepth {
+		return nil, w.lexer.errorf(t.pos, "expression is nested too deeply")
+	}
+
+	w.lexer.next()
+	w.depth++
+
+	expr, err := w.parseOr()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.lexer.expect(tokenSymbol, ")"); err != nil {
+		return nil, err
+	}
+
+	w.depth--
+
+	return expr, nil
+}
+
+func (w *whereParser) parseComparison() (Expr, error) {
+	name, err := w.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if name.kind != tokenWord {
+		return nil, w.lexer.errorf(name.pos, "expected field, got %s", name)
+	}
+
+	op, err := w.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if op.kind != tokenWord {
+		return nil, w.lexer.errorf(op.pos, "expected filter operator, got %s", op)
+	}
+
+	filter := strings.ToLower(op.text)
+
+	if w.filters != nil {
+		mapped, ok := w.filters[filter]
+
+		if !ok {
+			return nil, newFieldError(CodeUnknownOperator, w.param, w.path(name.text), op.text, "", ErrUnknownOperator)
+		}
+
+		filter = mapped
+	}
+
+	args, literal, err := w.parseArgs(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filtering, err := w.parser.newExprFiltering(w.param, w.path(name.text), filter, args, literal)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filtering, nil
+}
+
+func (w *whereParser) parseFunction() (Expr, error) {
+	function, err := w.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.lexer.expect(tokenSymbol, "("); err != nil {
+		return nil, err
+	}
+
+	name, err := w.lexer.next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if name.kind != tokenWord {
+		return nil, w.lexer.errorf(name.pos, "expected field, got %s", name)
+	}
+
+	if _, err := w.lexer.expect(tokenSymbol, ","); err != nil {
+		return nil, err
+	}
+
+	value, err := w.parseValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.lexer.expect(tokenSymbol, ")"); err != nil {
+		return nil, err
+	}
+
+	filter := w.functions[strings.ToLower(function.text)]
+	filtering, err := w.parser.newExprFiltering(w.param, w.path(name.text), filter, []string{value.text}, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filtering, nil
+}
+
+func (w *whereParser) path(name string) string {
+	if len(w.pathSeparator) == 0 {
+		return name
+	}
+
+	return strings.ReplaceAll(name, w.pathSeparator, SeparatorSelector)
+}
+
+func (w *whereParser) parseArgs(filter string) ([]string, bool, error) {
+	for _, valueless := range valuelessFilterValues {
+		if filter == valueless {
+			return nil, false, nil
+		}
+	}
+
+	t, err := w.lexer.peek()
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !t.is(tokenSymbol, "(") {
+		value, err := w.parseValue()
+
+		return []string{value.text}, value.kind == tokenWord, err
+	}
+
+	w.lexer.next()
+
+	args := make([]string, 0)
+
+	for {
+		value, err := w.parseValue()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		args = append(args, value.text)
+
+		t, err := w.lexer.next()
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		if t.is(tokenSymbol, ")") {
+			return args, false, nil
+		}
+
+		if !t.is(tokenSymbol, ",") {
+			return nil, false, w.lexer.errorf(t.pos, "expected \",\" or \")\", got %s", t)
+		}
+	}
+}
+
+func (w *whereParser) parseValue() (token, error) {
+	t, err := w.lexer.next()
+
+	if err != nil {
+		return t, err
+	}
+
+	if t.kind != tokenWord && t.kind != tokenString {
+		return t, w.lexer.errorf(t.pos, "expected value, got %s", t)
+	}
+
+	return t, nil
+}
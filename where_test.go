@@ -0,0 +1,150 @@
+package query_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/securehaven/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type exampleTicket struct {
+	Id       int                `json:"id"`
+	Status   string             `json:"status"`
+	Priority int                `json:"priority"`
+	Assignee query.Null[string] `json:"assignee"`
+}
+
+var ticketParser = query.MustParser(query.NewParser[exampleTicket]())
+
+func TestWhere(t *testing.T) {
+	t.Run("readme", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"(status eq 'open' or priority gte 3) and not assignee isnull"}}
+		expected := query.AndExpr{Exprs: []query.Expr{
+			query.OrExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "status", Filter: query.FilterEquals, Value: "open", Requested: "status", Column: "status"},
+				query.Filtering{Field: "priority", Filter: query.FilterGreateThanEquals, Value: 3, Requested: "priority", Column: "priority"},
+			}},
+			query.NotExpr{Expr: query.Filtering{Field: "assignee", Filter: query.FilterIsNull, Requested: "assignee", Column: "assignee"}},
+		}}
+		q, err := ticketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"id eq 1 or id eq 2 and status neq 'closed'"}}
+		expected := query.OrExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "id", Filter: query.FilterEquals, Value: 1, Requested: "id", Column: "id"},
+			query.AndExpr{Exprs: []query.Expr{
+				query.Filtering{Field: "id", Filter: query.FilterEquals, Value: 2, Requested: "id", Column: "id"},
+				query.Filtering{Field: "status", Filter: query.FilterNotEquals, Value: "closed", Requested: "status", Column: "status"},
+			}},
+		}}
+		q, err := ticketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"status in ('open', 'it''s pending') AND priority BETWEEN (1, 5)"}}
+		expected := query.AndExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "status", Filter: query.FilterIn, Value: []string{"open", "it's pending"}, Requested: "status", Column: "status"},
+			query.Filtering{Field: "priority", Filter: query.FilterBetween, Value: query.Range{Lower: 1, Upper: 5}, Requested: "priority", Column: "priority"},
+		}}
+		q, err := ticketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("null", func(t *testing.T) {
+		values := url.Values{query.ParamFilter: {"assignee neq null or assignee eq 'null'"}}
+		expected := query.OrExpr{Exprs: []query.Expr{
+			query.Filtering{Field: "assignee", Filter: query.FilterNotNull, Requested: "assignee", Column: "assignee"},
+			query.Filtering{Field: "assignee", Filter: query.FilterEquals, Value: "null", Requested: "assignee", Column: "assignee"},
+		}}
+		q, err := ticketParser.Parse(values)
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Equal(t, expected, q.Where, "expression should be equal")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		q, err := ticketParser.Parse(url.Values{})
+
+		assert.NoError(t, err, "should not return an error")
+		assert.Nil(t, q.Where, "expression should be nil")
+	})
+
+	t.Run("syntax-error", func(t *testing.T) {
+		cases := map[string]int{
+			"(status eq 'open'":   17,
+			"status eq 'open":     10,
+			"status eq 'a' and":   17,
+			"status eq 'a' )":     14,
+			"status in ('a' 'b')": 15,
+		}
+
+		for raw, pos := range cases {
+			_, err := ticketParser.Parse(url.Values{query.ParamFilter: {raw}})
+
+			var syntaxError query.SyntaxError
+			var fieldError query.FieldError
+
+			if assert.ErrorAs(t, err, &syntaxError, "should return a syntax error for %q", raw) {
+				assert.Equal(t, pos, syntaxError.Pos, "position should be equal for %q", raw)
+			}
+
+			if assert.ErrorAs(t, err, &fieldError, "should return a field error for %q", raw) {
+				assert.Equal(t, query.CodeInvalidSyntax, fieldError.Code)
+				assert.Equal(t, query.ParamFilter, fieldError.Param)
+			}
+		}
+	})
+
+	t.Run("semantic-error", func(t *testing.T) {
+		_, err := ticketParser.Parse(url.Values{query.ParamFilter: {"created_at eq 1"}})
+
+		assert.ErrorIs(t, err, query.ErrUnknownField, "should reject unknown field")
+
+		_, err = ticketParser.Parse(url.Values{query.ParamFilter: {"not (id foo 1)"}})
+
+		assert.ErrorIs(t, err, query.ErrUnknownOperator, "should reject unknown operator")
+
+		_, err = ticketParser.Parse(url.Values{query.ParamFilter: {"priority contains '1'"}})
+
+		assert.ErrorIs(t, err, query.ErrUnsupportedOperator, "should reject unsupported operator")
+
+		_, err = ticketParser.Parse(url.Values{query.ParamFilter: {"priority eq high"}})
+
+		var fieldError query.FieldError
+
+		if assert.ErrorAs(t, err, &fieldError, "should return a field error") {
+			assert.Equal(t, query.CodeInvalidValue, fieldError.Code)
+			assert.Equal(t, "priority", fieldError.Field)
+		}
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		raw := strings.Repeat("(", 100) + "id eq 1" + strings.Repeat(")", 100)
+		_, err := ticketParser.Parse(url.Values{query.ParamFilter: {raw}})
+
+		var syntaxError query.SyntaxError
+
+		assert.ErrorAs(t, err, &syntaxError, "should reject deep nesting")
+
+		raw = strings.Repeat("not ", 250000) + "id eq 1"
+		_, err = ticketParser.Parse(url.Values{query.ParamFilter: {raw}})
+
+		assert.ErrorAs(t, err, &syntaxError, "should reject deeply chained not")
+
+		raw = strings.Repeat("not ", 4) + "id eq 1"
+		_, err = ticketParser.Parse(url.Values{query.ParamFilter: {raw}})
+
+		assert.NoError(t, err, "should accept a few chained not")
+	})
+}